@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"GDGOC-API/internal/catalog"
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/database"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+	"GDGOC-API/internal/services"
+)
+
+// go run ./cmd import --file menu.csv [--dry-run] [--upsert-by name|external_id]
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := flags.String("file", "", "path file CSV/XLSX/JSONL yang akan diimport")
+	format := flags.String("format", "", "format file (csv|xlsx|jsonl), default dari ekstensi")
+	dryRun := flags.Bool("dry-run", false, "tampilkan hasil tanpa menyimpan ke database")
+	upsertBy := flags.String("upsert-by", models.ImportUpsertByName, "kunci upsert: name atau external_id")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "--file wajib diisi")
+		flags.Usage()
+		return 2
+	}
+
+	detected, err := catalog.DetectFormat(*file, *format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v: %s\n", err, *file)
+		return 2
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Gagal membuka file: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	config.LoadConfig()
+	database.ConnectDatabase()
+	defer database.CloseDatabase()
+	database.AutoMigrate()
+
+	// embedding menu yang diimport ikut disimpan, index in-memory dibangun ulang saat server start
+	menuRepo := repositories.NewMenuRepository(database.GetDB())
+	semanticService := services.NewSemanticSearchService(menuRepo, repositories.NewEmbeddingRepository(database.GetDB()), setupEmbedder())
+	menuService := services.NewMenuService(menuRepo, semanticService, config.GetConfig().MenuUniqueName, nil)
+	report, err := menuService.ImportMenus(context.Background(), f, models.ImportOptions{
+		Format:   detected,
+		DryRun:   *dryRun,
+		UpsertBy: *upsertBy,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import gagal: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -1,17 +1,28 @@
 package main
 
 import(
+	"GDGOC-API/internal/auth"
 	"GDGOC-API/internal/config"
 	"GDGOC-API/internal/database"
+	"GDGOC-API/internal/embedding"
+	"GDGOC-API/internal/events"
 	"GDGOC-API/internal/gemini"
+	"GDGOC-API/internal/graphql"
+	"GDGOC-API/internal/grpcapi"
 	"GDGOC-API/internal/handlers"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/middleware"
 	"GDGOC-API/internal/repositories"
 	"GDGOC-API/internal/routes"
 	"GDGOC-API/internal/services"
+	"crypto/rand"
 	"log"
+	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -20,6 +31,20 @@ import(
 )
 
 func main(){
+	// subcommand CLI
+	if len(os.Args) > 1{
+		switch os.Args[1]{
+		case "import":
+			os.Exit(runImport(os.Args[2:]))
+		case "openapi":
+			os.Exit(runOpenAPI(os.Args[2:]))
+		case "webhook-receiver":
+			os.Exit(runWebhookReceiver(os.Args[2:]))
+		case "user":
+			os.Exit(runUser(os.Args[2:]))
+		}
+	}
+
 	// loading
 	log.Println("Loading konfigurasi...")
 	config.LoadConfig()
@@ -28,6 +53,7 @@ func main(){
 	log.Println("Menghubungkan ke database...")
 	database.ConnectDatabase()
 	defer database.CloseDatabase()
+	database.AutoMigrate()
 
 	// ✅ DEKLARASI VARIABLE DI SCOPE YANG SAMA
 	var geminiService *gemini.Service
@@ -53,10 +79,65 @@ func main(){
 	log.Println("Inisialisasi layer...")
 
 	menuRepo := repositories.NewMenuRepository(database.GetDB())
-	menuService := services.NewMenuService(menuRepo)
+
+	// embedding untuk semantic search
+	semanticService := services.NewSemanticSearchService(menuRepo, repositories.NewEmbeddingRepository(database.GetDB()), setupEmbedder())
+	if err := semanticService.Warmup(); err != nil {
+		log.Printf("Gagal menyiapkan index semantik: %v", err)
+	}
+
+	// perubahan katalog disiarkan ke subscriber (SSE/WebSocket & gRPC WatchCatalog)
+	catalogEvents := events.NewBroker(config.GetConfig().EventLogSize)
+
+	// webhook: event katalog dikirim ke penerima terdaftar dengan retry & dead-letter
+	cfg := config.GetConfig()
+	webhookService := services.NewWebhookService(repositories.NewWebhookRepository(database.GetDB()), services.WebhookOptions{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		BackoffBase: time.Duration(cfg.WebhookBackoffSeconds)*time.Second,
+		BackoffMax: time.Duration(cfg.WebhookBackoffMaxSeconds)*time.Second,
+		Timeout: time.Duration(cfg.WebhookTimeoutSeconds)*time.Second,
+		AllowPrivateNetworks: cfg.WebhookAllowPrivateNetworks,
+	})
+	webhookService.Start()
+
+	// perubahan menu ditulis ke outbox dalam transaksi yang sama, relay meneruskan ke sink
+	outboxRelay := services.NewOutboxRelay(repositories.NewOutboxRepository(database.GetDB()), setupSinks(catalogEvents, webhookService), services.OutboxOptions{
+		Retention: time.Duration(cfg.OutboxRetentionHours)*time.Hour,
+		MaxAttempts: cfg.OutboxMaxAttempts,
+	})
+	outboxRelay.Start()
+	menuService := services.NewMenuService(menuRepo, semanticService, config.GetConfig().MenuUniqueName, outboxRelay)
 	
 	// ✅ SEKARANG geminiService SUDAH TERDEFINISI DI SCOPE INI
-	menuHandler := handlers.NewMenuHandler(menuService, geminiService) 
+	analyticsService := services.NewAnalyticsService(repositories.NewAnalyticsRepository(database.GetDB()))
+	menuHandler := handlers.NewMenuHandler(menuService, geminiService, analyticsService)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+
+	// GraphQL memakai service & alur rekomendasi yang sama dengan REST
+	graphqlServer, err := graphql.NewServer(
+		graphql.NewResolver(menuService, analyticsService, menuHandler.Recommend),
+		graphql.Limits{
+			MaxDepth: config.GetConfig().GraphQLMaxDepth,
+			MaxComplexity: config.GetConfig().GraphQLMaxComplexity,
+		},
+	)
+	if err != nil{
+		log.Fatalf("Gagal membuat schema GraphQL: %v", err)
+	}
+	graphqlHandler := handlers.NewGraphQLHandler(graphqlServer)
+
+	// autentikasi: JWT untuk user lokal, API key untuk server-to-server
+	authService := services.NewAuthService(repositories.NewAuthRepository(database.GetDB()), setupTokens())
+	if err := authService.Bootstrap(cfg.AuthBootstrapUsername, cfg.AuthBootstrapPassword); err != nil{
+		log.Fatalf("Gagal membuat user awal: %v", err)
+	}
+	if !cfg.AuthEnabled{
+		log.Println("AUTH_ENABLED=false - semua endpoint terbuka tanpa autentikasi")
+	}
+	authHandler := handlers.NewAuthHandler(authService)
+	eventHandler := handlers.NewEventHandler(catalogEvents)
+
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
 
 	log.Println("Creating Fiber app...")
 	app := fiber.New(fiber.Config{
@@ -65,21 +146,46 @@ func main(){
 		ErrorHandler: customErrorHandler,
 	})
 
+	// recover, logger & CORS dipasang paling awal supaya berlaku untuk semua route
+	setupMiddleware(app)
+
+	// bahasa response dari Accept-Language
+	app.Use(middleware.Language())
+
+	// principal dari Authorization / X-API-Key, harus sebelum Idempotency
+	// supaya Idempotency-Key dipisah per principal
+	if cfg.AuthEnabled{
+		app.Use(middleware.Authenticate(authService))
+	}
+
+	// Idempotency-Key untuk endpoint tulis, dipasang sebelum route
+	idempotencyService := services.NewIdempotencyService(
+		repositories.NewIdempotencyRepository(database.GetDB()),
+		time.Duration(config.GetConfig().IdempotencyTTLHours)*time.Hour,
+		time.Duration(config.GetConfig().IdempotencyLockSeconds)*time.Second,
+	)
+	idempotencyService.StartCleanup(time.Hour)
+	app.Use(middleware.Idempotency(idempotencyService))
+
 	// setup route
 	log.Println("Setting route...")
-	routes.SetupRoutes(app, menuHandler)
+	routes.SetupRoutes(app, menuHandler, analyticsHandler, graphqlHandler, eventHandler, webhookHandler, authHandler)
 
-	// middleware
-	setupMiddleware(app)
+	// 404 untuk path yang tidak cocok dengan route mana pun, harus paling akhir
+	app.Use(func(c *fiber.Ctx) error{
+		return services.Errorf(services.KindNotFound, services.CodeNotFound, i18n.MsgEndpointNotFound)
+	})
 
 	
 	// start
 	port := config.GetConfig().Port
 	log.Println("Server siap digunakan")
 	log.Printf("Server dijalankan di http://localhost:%s\n", port)
-	log.Printf("API Base : http://localhost:%s\n", port)
+	log.Printf("API Base : http://localhost:%s%s\n", port, routes.APIV1)
 	log.Printf("Health check: http://localhost:%s/health\n", port)
-	log.Printf("AI Recommendations: POST http://localhost:%s/menu/recommendations\n", port)
+	log.Printf("AI Recommendations: POST http://localhost:%s%s/menu/recommendations\n", port, routes.APIV1)
+	log.Printf("GraphQL: POST http://localhost:%s%s/graphql\n", port, routes.APIV1)
+	log.Printf("Catalog events: http://localhost:%s%s/menu/events (SSE), /menu/events/ws (WebSocket)\n", port, routes.APIV1)
 
 	// shutdown
 	go func() {
@@ -88,18 +194,99 @@ func main(){
 		}
 	}()
 
+	// gRPC di port terpisah, service & event katalog sama dengan HTTP
+	var grpcServer *grpcapi.Server
+	if config.GetConfig().GRPCEnabled{
+		grpcPort := config.GetConfig().GRPCPort
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil{
+			log.Fatalf("Gagal membuka port gRPC %s: %v", grpcPort, err)
+		}
+		var grpcAuth *services.AuthService
+		if cfg.AuthEnabled{
+			grpcAuth = authService
+		}
+		grpcServer = grpcapi.NewServer(menuService, analyticsService, catalogEvents, grpcAuth)
+		log.Printf("gRPC: localhost:%s\n", grpcPort)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil{
+				log.Fatalf("Server gRPC gagal dijalankan: %v", err)
+			}
+		}()
+	}
+
 	// interupsi
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	<-c
 
 	log.Println("Mematikan server...")
+	if grpcServer != nil{
+		grpcServer.Shutdown()
+	}
+	// stream SSE/WebSocket ditutup dulu, shutdown menunggu semua koneksi selesai
+	eventHandler.Close()
 	if err := app.Shutdown(); err != nil{
 		log.Printf("Server shutdown error: %v", err)
 	}
 	log.Println("Server berhasil dimatikan")
 }
 
+// pilih embedding provider, fallback ke hash embedder lokal
+func setupEmbedder() embedding.Provider {
+	cfg := config.GetConfig()
+	provider := cfg.EmbeddingProvider
+	if provider == "" {
+		provider = "hash"
+		if cfg.GeminiAPIKey != "" {
+			provider = "gemini"
+		}
+	}
+
+	if provider == "gemini" {
+		embedder, err := gemini.NewEmbedder(cfg.GeminiAPIKey)
+		if err == nil {
+			log.Println("Embedding provider: Gemini")
+			return embedder
+		}
+		log.Printf("Gagal inisialisasi Gemini embedder: %v - pakai hash embedder", err)
+	}
+
+	log.Println("Embedding provider: hash lokal")
+	return embedding.NewHashEmbedder(cfg.EmbeddingDimensions)
+}
+
+// sink relay outbox dari OUTBOX_SINKS (broker, webhook, log, nats)
+func setupSinks(broker *events.Broker, webhookService *services.WebhookService) []events.Sink {
+	cfg := config.GetConfig()
+	var sinks []events.Sink
+	for _, name := range strings.Split(cfg.OutboxSinks, ","){
+		switch strings.TrimSpace(name){
+		case "":
+		case "broker":
+			sinks = append(sinks, events.NewBrokerSink(broker))
+		case "webhook":
+			sinks = append(sinks, webhookService)
+		case "log":
+			sinks = append(sinks, events.NewLogSink(nil))
+		case "nats":
+			sink, err := events.NewNATSSink(cfg.NATSURL, cfg.NATSSubject, cfg.NATSJetStream, 5*time.Second)
+			if err != nil{
+				log.Fatalf("NATS_URL tidak valid: %v", err)
+			}
+			sinks = append(sinks, sink)
+		default:
+			log.Fatalf("Sink outbox tidak dikenal: %s", name)
+		}
+	}
+	names := make([]string, len(sinks))
+	for i, sink := range sinks{
+		names[i] = sink.Name()
+	}
+	log.Printf("Outbox sink: %s", strings.Join(names, ", "))
+	return sinks
+}
+
 // setup middleware
 func setupMiddleware(app *fiber.App){
 	app.Use(recover.New(recover.Config{
@@ -115,35 +302,77 @@ func setupMiddleware(app *fiber.App){
 		}))
 	}
 
-	// CORS
+	// CORS, hanya origin di CORS_ALLOW_ORIGINS
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowOrigins: config.GetConfig().CORSAllowOrigins,
+		AllowMethods: "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders: "Origin, Content-Type, Accept, Accept-Language, Authorization, X-API-Key, If-Match, If-None-Match, Idempotency-Key",
+		ExposeHeaders: "Content-Language, ETag, Idempotent-Replayed, Deprecation, Sunset, Link, WWW-Authenticate",
 	}))
+}
 
-	// 404 handler
-	app.Use(func(c *fiber.Ctx) error{
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Endpoint tidak ditemukan",
-			"path": c.Path(),
-		})
-	})
+// kunci JWT dari config: HS256 dengan JWT_SECRET atau RS256 dengan file PEM
+func setupTokens() *auth.TokenManager {
+	cfg := config.GetConfig()
+	tokenConfig := auth.TokenConfig{
+		Algorithm: cfg.JWTAlgorithm,
+		Secret: []byte(cfg.JWTSecret),
+		Issuer: cfg.JWTIssuer,
+		Audience: cfg.JWTAudience,
+		TTL: time.Duration(cfg.JWTTTLMinutes)*time.Minute,
+	}
+
+	switch cfg.JWTAlgorithm{
+	case auth.HS256:
+		if cfg.JWTSecret == ""{
+			if cfg.AppEnv == "production"{
+				log.Fatal("JWT_SECRET wajib di set di production")
+			}
+			// secret acak per proses: token tidak berlaku lagi setelah restart
+			secret := make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil{
+				log.Fatalf("Gagal membuat JWT secret: %v", err)
+			}
+			tokenConfig.Secret = secret
+			log.Println("JWT_SECRET belum di set - memakai secret acak, token tidak berlaku lagi setelah restart")
+		}
+	case auth.RS256:
+		if cfg.JWTPrivateKeyFile != ""{
+			data, err := os.ReadFile(cfg.JWTPrivateKeyFile)
+			if err == nil{
+				tokenConfig.PrivateKey, err = auth.ParseRSAPrivateKey(data)
+			}
+			if err != nil{
+				log.Fatalf("Gagal membaca JWT_PRIVATE_KEY_FILE: %v", err)
+			}
+		}
+		if cfg.JWTPublicKeyFile != ""{
+			data, err := os.ReadFile(cfg.JWTPublicKeyFile)
+			if err == nil{
+				tokenConfig.PublicKey, err = auth.ParseRSAPublicKey(data)
+			}
+			if err != nil{
+				log.Fatalf("Gagal membaca JWT_PUBLIC_KEY_FILE: %v", err)
+			}
+		}
+	}
+
+	tokens, err := auth.NewTokenManager(tokenConfig)
+	if err != nil{
+		log.Fatalf("Konfigurasi JWT tidak valid: %v", err)
+	}
+	log.Printf("JWT: %s", cfg.JWTAlgorithm)
+	return tokens
 }
 
-// error handler
+// error handler, semua error dikirim sebagai problem+json (RFC 7807)
 func customErrorHandler(c *fiber.Ctx, err error) error{
-	code := fiber.StatusInternalServerError
+	problem := handlers.NewProblem(c, err)
 
-	if e, ok := err.(*fiber.Error); ok{
-		code = e.Code
+	if problem.Status >= fiber.StatusInternalServerError{
+		log.Printf("Error : %v", err)
 	}
 
-	log.Printf("Error : %v", err)
-
 	//return
-	return c.Status(code).JSON(fiber.Map{
-		"message": err.Error(),
-		"status": code,
-	})
+	return c.Status(problem.Status).JSON(problem, handlers.MIMEProblemJSON)
 }
\ No newline at end of file
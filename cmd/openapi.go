@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"GDGOC-API/internal/routes"
+)
+
+// go run ./cmd openapi [--out openapi.json]
+// exit 1 kalau ada route yang belum didokumentasikan, bisa dipakai di CI
+func runOpenAPI(args []string) int {
+	flags := flag.NewFlagSet("openapi", flag.ContinueOnError)
+	out := flags.String("out", "", "file tujuan, default stdout")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	// handler hanya dibaca sebagai method value, tidak dipanggil
+	spec, err := routes.BuildSpec(routes.V1Routes(nil, nil, nil, nil, nil, nil), routes.V1Docs())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Dokumentasi OpenAPI tidak lengkap: %v\n", err)
+		return 1
+	}
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Gagal membuat spesifikasi: %v\n", err)
+		return 1
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Gagal menulis %s: %v\n", *out, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/database"
+	"GDGOC-API/internal/repositories"
+	"GDGOC-API/internal/services"
+)
+
+// go run ./cmd user --username admin --password rahasia123 [--role manager]
+// buat user lokal (untuk POST /auth/token) atau ganti password user yang sudah ada
+func runUser(args []string) int {
+	flags := flag.NewFlagSet("user", flag.ContinueOnError)
+	username := flags.String("username", "", "username")
+	password := flags.String("password", "", "password (minimal 8 karakter), kosong = dari env USER_PASSWORD")
+	roles := flags.String("role", "", "role yang diberikan, dipisah koma (cashier, editor, manager, admin)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *password == "" {
+		*password = os.Getenv("USER_PASSWORD")
+	}
+	if *username == "" || *password == "" {
+		fmt.Fprintln(os.Stderr, "--username & --password wajib diisi")
+		flags.Usage()
+		return 2
+	}
+
+	config.LoadConfig()
+	database.ConnectDatabase()
+	defer database.CloseDatabase()
+	database.AutoMigrate()
+
+	// token tidak dibuat di sini, cukup repository user
+	authService := services.NewAuthService(repositories.NewAuthRepository(database.GetDB()), nil)
+	user, err := authService.SaveUser(*username, *password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Gagal menyimpan user: %v\n", err)
+		return 1
+	}
+	if *roles != "" {
+		if err := authService.GrantRoles(user, strings.Split(*roles, ",")); err != nil {
+			fmt.Fprintf(os.Stderr, "Gagal memberi role: %v\n", err)
+			return 1
+		}
+	}
+	fmt.Printf("User %s (id %d) disimpan\n", user.Username, user.ID)
+	return 0
+}
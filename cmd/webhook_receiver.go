@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"GDGOC-API/internal/webhook"
+)
+
+// go run ./cmd webhook-receiver --secret whsec_... [--addr :4000] [--fail 2] [--status 500]
+// penerima webhook lokal untuk uji coba: cek signature, tampilkan event, dan bisa
+// sengaja gagal N kali pertama per pengiriman untuk menguji retry & dead-letter
+func runWebhookReceiver(args []string) int {
+	flags := flag.NewFlagSet("webhook-receiver", flag.ContinueOnError)
+	addr := flags.String("addr", ":4000", "alamat listen")
+	secret := flags.String("secret", "", "secret webhook (dari response create), kosong = signature tidak dicek")
+	fail := flags.Int("fail", 0, "balas gagal untuk N percobaan pertama setiap pengiriman, -1 = selalu gagal")
+	status := flags.Int("status", http.StatusInternalServerError, "status response saat gagal")
+	tolerance := flags.Duration("tolerance", 5*time.Minute, "toleransi umur X-Webhook-Timestamp")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		delivery := r.Header.Get(webhook.HeaderDelivery)
+		event := r.Header.Get(webhook.HeaderEvent)
+		if *secret != "" {
+			err := webhook.Verify(*secret, r.Header.Get(webhook.HeaderSignature), r.Header.Get(webhook.HeaderTimestamp), body, *tolerance)
+			if err != nil {
+				log.Printf("delivery %s (%s): signature ditolak: %v", delivery, event, err)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+
+		mu.Lock()
+		attempts[delivery]++
+		attempt := attempts[delivery]
+		mu.Unlock()
+
+		if *fail < 0 || attempt <= *fail {
+			log.Printf("delivery %s (%s) percobaan %d: sengaja gagal %d", delivery, event, attempt, *status)
+			http.Error(w, "simulated failure", *status)
+			return
+		}
+		log.Printf("delivery %s (%s) percobaan %d: diterima %s", delivery, event, attempt, body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("Webhook receiver di %s", *addr)
+	if err := http.ListenAndServe(*addr, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Receiver gagal dijalankan: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// prefix API key, format mk_<id publik 8 hex>_<secret 48 hex>
+const apiKeyPrefix = "mk_"
+
+// hash password user lokal (bcrypt)
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// hash kosong = user tidak ada, tetap menjalankan bcrypt supaya waktu respon
+// tidak membocorkan username mana yang terdaftar
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		dummyOnce.Do(func() {
+			generated, _ := bcrypt.GenerateFromPassword([]byte(randomHex(16)), bcrypt.DefaultCost)
+			dummyHash = string(generated)
+		})
+		bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// hash bcrypt dari string acak, hanya dipakai untuk menyamakan waktu respon
+var (
+	dummyHash string
+	dummyOnce sync.Once
+)
+
+// API key baru: key lengkap (hanya ditampilkan sekali), prefix untuk mencari key
+// di db & hash SHA-256 yang disimpan
+func NewAPIKey() (key, prefix, hash string) {
+	prefix = randomHex(4)
+	key = apiKeyPrefix + prefix + "_" + randomHex(24)
+	return key, prefix, HashAPIKey(key)
+}
+
+// prefix dari key, false kalau formatnya bukan API key
+func APIKeyPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != 8 || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// key cukup acak (192 bit) sehingga SHA-256 tanpa salt aman & cepat dicek per request
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func APIKeyMatches(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(hash)) == 1
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, hash := NewAPIKey()
+	if !strings.HasPrefix(key, apiKeyPrefix+prefix+"_") {
+		t.Fatalf("key %q tidak diawali prefix %q", key, prefix)
+	}
+	if got, ok := APIKeyPrefix(key); !ok || got != prefix {
+		t.Fatalf("APIKeyPrefix = %q, %v; want %q", got, ok, prefix)
+	}
+	if hash != HashAPIKey(key) || strings.Contains(hash, key) {
+		t.Fatal("hash harus SHA-256 dari key, bukan key itu sendiri")
+	}
+	if other, _, _ := NewAPIKey(); other == key {
+		t.Fatal("dua API key sama")
+	}
+}
+
+func TestAPIKeyMatches(t *testing.T) {
+	key, _, hash := NewAPIKey()
+	tests := []struct {
+		name string
+		key  string
+		hash string
+		want bool
+	}{
+		{name: "key benar", key: key, hash: hash, want: true},
+		{name: "secret diubah", key: key[:len(key)-1] + "x", hash: hash},
+		{name: "hash kosong", key: key, hash: ""},
+		{name: "hash dari key lain", key: key, hash: HashAPIKey("mk_00000000_abc")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := APIKeyMatches(tt.key, tt.hash); got != tt.want {
+				t.Fatalf("APIKeyMatches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyPrefix(t *testing.T) {
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{key: "mk_0a1b2c3d_secret", want: "0a1b2c3d", wantOK: true},
+		{key: "mk_0a1b2c3d_", wantOK: false},
+		{key: "mk_short_secret", wantOK: false},
+		{key: "0a1b2c3d_secret", wantOK: false},
+		{key: "eyJhbGciOiJIUzI1NiJ9.e30.sig", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := APIKeyPrefix(tt.key)
+			if ok != tt.wantOK || got != tt.want {
+				t.Fatalf("APIKeyPrefix(%q) = %q, %v; want %q, %v", tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("rahasia-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		want     bool
+	}{
+		{name: "benar", hash: hash, password: "rahasia-123", want: true},
+		{name: "salah", hash: hash, password: "rahasia-124"},
+		{name: "user tidak ada", hash: "", password: "rahasia-123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckPassword(tt.hash, tt.password); got != tt.want {
+				t.Fatalf("CheckPassword = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+)
+
+// jenis principal
+const (
+	PrincipalUser   = "user"
+	PrincipalAPIKey = "api_key"
+)
+
+// key c.Locals untuk principal request
+const LocalsKey = "principal"
+
+// pemanggil yang sudah terautentikasi: user lokal (JWT) atau API key (server-to-server)
+type Principal struct {
+	Type string `json:"type"`
+	// id user / id API key, 0 untuk subject token dari issuer lain
+	ID      uint   `json:"id,omitempty"`
+	Subject string `json:"subject"`
+	Name    string `json:"name"`
+	// role dari role assignment & gabungan permission-nya
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// set role principal beserta permission-nya
+func (p *Principal) SetRoles(roles []string) {
+	p.Roles = roles
+	p.Permissions = RolePermissions(roles)
+}
+
+// principal punya permission perm
+func (p *Principal) Can(perm string) bool {
+	for _, granted := range p.Permissions {
+		if granted == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// identitas unik lintas jenis principal, mis. user:3 atau api_key:7
+func (p *Principal) Key() string {
+	if p.ID == 0 {
+		return p.Type + ":" + p.Subject
+	}
+	return p.Type + ":" + strconv.FormatUint(uint64(p.ID), 10)
+}
+
+type principalKey struct{}
+
+// context dengan principal, dipakai di luar fiber (gRPC, GraphQL)
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// principal dari context, nil kalau belum terautentikasi
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
@@ -0,0 +1,75 @@
+package auth
+
+import "sort"
+
+// permission, dicek per route (routes.routePermissions), per RPC gRPC & per field GraphQL
+const (
+	PermMenuRead      = "menu:read"
+	PermMenuWrite     = "menu:write"
+	PermMenuDelete    = "menu:delete"
+	PermPriceWrite    = "price:write"
+	PermAIUse         = "ai:use"
+	PermReportRead    = "report:read"
+	PermWebhookManage = "webhook:manage"
+	PermAuthManage    = "auth:manage"
+)
+
+// role bawaan, tiap role mencakup semua permission role di bawahnya
+const (
+	RoleCashier = "cashier"
+	RoleEditor  = "editor"
+	RoleManager = "manager"
+	RoleAdmin   = "admin"
+)
+
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+var roles = []Role{
+	{RoleCashier, "Lihat katalog & rekomendasi", []string{
+		PermMenuRead, PermAIUse,
+	}},
+	{RoleEditor, "Cashier + buat & ubah menu (tanpa ubah harga)", []string{
+		PermMenuRead, PermAIUse, PermMenuWrite,
+	}},
+	{RoleManager, "Editor + hapus menu, ubah harga & laporan", []string{
+		PermMenuRead, PermAIUse, PermMenuWrite, PermMenuDelete, PermPriceWrite, PermReportRead,
+	}},
+	{RoleAdmin, "Manager + webhook, API key & role assignment", []string{
+		PermMenuRead, PermAIUse, PermMenuWrite, PermMenuDelete, PermPriceWrite, PermReportRead, PermWebhookManage, PermAuthManage,
+	}},
+}
+
+// semua role bawaan
+func Roles() []Role {
+	return roles
+}
+
+func LookupRole(name string) (Role, bool) {
+	for _, role := range roles {
+		if role.Name == name {
+			return role, true
+		}
+	}
+	return Role{}, false
+}
+
+// gabungan permission beberapa role (urut), role yang tidak dikenal diabaikan
+func RolePermissions(names []string) []string {
+	set := make(map[string]bool)
+	for _, name := range names {
+		role, _ := LookupRole(name)
+		for _, perm := range role.Permissions {
+			set[perm] = true
+		}
+	}
+	permissions := make([]string, 0, len(set))
+	for perm := range set {
+		permissions = append(permissions, perm)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// algoritma JWT yang didukung
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+var (
+	// token rusak, signature salah, algoritma lain, issuer/audience tidak cocok
+	ErrInvalidToken = errors.New("token tidak valid")
+	ErrTokenExpired = errors.New("token sudah kedaluwarsa")
+	// RS256 hanya dengan public key: token bisa diverifikasi tapi tidak bisa dibuat di sini
+	ErrCannotSign = errors.New("private key JWT tidak dikonfigurasi")
+)
+
+// toleransi selisih jam antar server untuk exp & nbf
+const clockLeeway = 30 * time.Second
+
+// klaim JWT yang dipakai API ini. aud boleh string atau array string
+// (jwt.ClaimStrings punya UnmarshalJSON untuk keduanya)
+type Claims struct {
+	Name string `json:"name,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// konfigurasi kunci JWT lokal
+type TokenConfig struct {
+	// HS256 atau RS256
+	Algorithm string
+	// secret HS256
+	Secret []byte
+	// RS256: private key untuk membuat token (opsional kalau token dibuat pihak lain),
+	// public key untuk verifikasi (default dari private key)
+	PrivateKey *rsa.PrivateKey
+	PublicKey  *rsa.PublicKey
+	Issuer     string
+	Audience   string
+	TTL        time.Duration
+}
+
+// buat & verifikasi JWT. Algoritma di header token harus sama dengan konfigurasi,
+// jadi token "none" atau HS256 yang ditandatangani dengan public key RS256 ditolak
+type TokenManager struct {
+	cfg TokenConfig
+}
+
+func NewTokenManager(cfg TokenConfig) (*TokenManager, error) {
+	switch cfg.Algorithm {
+	case HS256:
+		if len(cfg.Secret) < 32 {
+			return nil, errors.New("secret HS256 minimal 32 byte")
+		}
+	case RS256:
+		if cfg.PublicKey == nil && cfg.PrivateKey != nil {
+			cfg.PublicKey = &cfg.PrivateKey.PublicKey
+		}
+		if cfg.PublicKey == nil {
+			return nil, errors.New("RS256 butuh private key atau public key")
+		}
+	default:
+		return nil, fmt.Errorf("algoritma JWT tidak didukung: %s", cfg.Algorithm)
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = time.Hour
+	}
+	return &TokenManager{cfg: cfg}, nil
+}
+
+// lama berlaku token yang dibuat Issue
+func (m *TokenManager) TTL() time.Duration {
+	return m.cfg.TTL
+}
+
+// buat token untuk subject, iat/exp/iss/aud/jti diisi dari konfigurasi
+func (m *TokenManager) Issue(subject, name string, now time.Time) (string, Claims, error) {
+	claims := Claims{
+		Name: name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    m.cfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.cfg.TTL)),
+			ID:        randomHex(16),
+		},
+	}
+	if m.cfg.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.cfg.Audience}
+	}
+	token, err := m.Sign(claims)
+	return token, claims, err
+}
+
+// tanda tangani klaim apa adanya
+func (m *TokenManager) Sign(claims Claims) (string, error) {
+	if m.cfg.Algorithm == HS256 {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.cfg.Secret)
+	}
+	if m.cfg.PrivateKey == nil {
+		return "", ErrCannotSign
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(m.cfg.PrivateKey)
+}
+
+// cek signature, algoritma (hanya yang dikonfigurasi), exp (wajib), nbf, iss & aud
+func (m *TokenManager) Verify(token string, now time.Time) (*Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{m.cfg.Algorithm}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockLeeway),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	}
+	if m.cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(m.cfg.Issuer))
+	}
+	if m.cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(m.cfg.Audience))
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, m.key, options...)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrTokenExpired
+	}
+	if err != nil || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+// kunci verifikasi sesuai algoritma yang dikonfigurasi
+func (m *TokenManager) key(*jwt.Token) (interface{}, error) {
+	if m.cfg.Algorithm == HS256 {
+		return m.cfg.Secret, nil
+	}
+	return m.cfg.PublicKey, nil
+}
+
+// private key RSA dari PEM (PKCS#1 atau PKCS#8)
+func ParseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	return jwt.ParseRSAPrivateKeyFromPEM(data)
+}
+
+// public key RSA dari PEM (PKIX, PKCS#1 atau sertifikat)
+func ParseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	return jwt.ParseRSAPublicKeyFromPEM(data)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testNow = time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+func newRSAManager(t *testing.T) (*TokenManager, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewTokenManager(TokenConfig{
+		Algorithm:  RS256,
+		PrivateKey: key,
+		Issuer:     "menu-catalog-api",
+		Audience:   "menu-catalog-api",
+		TTL:        time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, key
+}
+
+// token dengan header & payload bebas, signature dari sign (nil = kosong)
+func rawToken(t *testing.T, header, payload map[string]interface{}, sign func(input string) []byte) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := encode(header) + "." + encode(payload)
+	var signature []byte
+	if sign != nil {
+		signature = sign(input)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	m, key := newRSAManager(t)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)})
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "7",
+			"iss": "menu-catalog-api",
+			"aud": "menu-catalog-api",
+			"iat": testNow.Unix(),
+			"exp": testNow.Add(time.Hour).Unix(),
+		}
+	}
+	signRS := func(claims map[string]interface{}) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims(claims)).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := valid()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: signRS(valid())},
+		{name: "aud array", token: signRS(with("aud", []string{"lain", "menu-catalog-api"}))},
+		{
+			name:    "alg none",
+			token:   rawToken(t, map[string]interface{}{"alg": "none", "typ": "JWT"}, valid(), nil),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "HS256 ditandatangani dengan public key RS256",
+			token: func() string {
+				token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(valid())).SignedString(publicPEM)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return token
+			}(),
+			wantErr: ErrInvalidToken,
+		},
+		{name: "kedaluwarsa", token: signRS(with("exp", testNow.Add(-time.Minute).Unix())), wantErr: ErrTokenExpired},
+		{name: "masih dalam leeway", token: signRS(with("exp", testNow.Add(-10*time.Second).Unix()))},
+		{name: "tanpa exp", token: signRS(with("exp", nil)), wantErr: ErrInvalidToken},
+		{name: "nbf di masa depan", token: signRS(with("nbf", testNow.Add(time.Minute).Unix())), wantErr: ErrInvalidToken},
+		{name: "aud lain", token: signRS(with("aud", "aplikasi-lain")), wantErr: ErrInvalidToken},
+		{name: "aud array tanpa API ini", token: signRS(with("aud", []string{"a", "b"})), wantErr: ErrInvalidToken},
+		{name: "iss lain", token: signRS(with("iss", "issuer-lain")), wantErr: ErrInvalidToken},
+		{name: "tanpa sub", token: signRS(with("sub", nil)), wantErr: ErrInvalidToken},
+		{name: "signature diubah", token: signRS(valid())[:len(signRS(valid()))-4] + "AAAA", wantErr: ErrInvalidToken},
+		{name: "bukan JWT", token: "abc.def", wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := m.Verify(tt.token, testNow)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.Subject != "7" {
+				t.Fatalf("sub = %q, want 7", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestIssueRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  TokenConfig
+	}{
+		{name: "HS256", cfg: TokenConfig{Algorithm: HS256, Secret: []byte(strings.Repeat("s", 32)), Audience: "menu-catalog-api"}},
+		{name: "HS256 tanpa audience", cfg: TokenConfig{Algorithm: HS256, Secret: []byte(strings.Repeat("s", 32))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewTokenManager(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, issued, err := m.Issue("7", "kasir", testNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			claims, err := m.Verify(token, testNow.Add(time.Minute))
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.Subject != "7" || claims.Name != "kasir" || claims.ID != issued.ID {
+				t.Fatalf("klaim = %+v, want %+v", claims, issued)
+			}
+			if _, err := m.Verify(token, testNow.Add(2*time.Hour)); !errors.Is(err, ErrTokenExpired) {
+				t.Fatalf("Verify setelah TTL error = %v, want ErrTokenExpired", err)
+			}
+		})
+	}
+}
+
+func TestSignWithoutPrivateKey(t *testing.T) {
+	_, key := newRSAManager(t)
+	m, err := NewTokenManager(TokenConfig{Algorithm: RS256, PublicKey: &key.PublicKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.Issue("7", "", testNow); !errors.Is(err, ErrCannotSign) {
+		t.Fatalf("Issue error = %v, want ErrCannotSign", err)
+	}
+}
+
+func TestClaimsAudience(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    []string
+	}{
+		{payload: `{"aud":"menu-catalog-api"}`, want: []string{"menu-catalog-api"}},
+		{payload: `{"aud":["a","b"]}`, want: []string{"a", "b"}},
+		{payload: `{}`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			var claims Claims
+			if err := json.Unmarshal([]byte(tt.payload), &claims); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(claims.Audience, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("aud = %v, want %v", claims.Audience, tt.want)
+			}
+		})
+	}
+	var claims Claims
+	if err := json.Unmarshal([]byte(`{"aud":123}`), &claims); err == nil {
+		t.Fatal("aud angka harus ditolak")
+	}
+}
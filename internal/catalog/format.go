@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatXLSX  = "xlsx"
+)
+
+// pemisah antar bahan di kolom ingredients (CSV & XLSX)
+const IngredientSeparator = "|"
+
+var ErrUnsupportedFormat = errors.New("format file tidak didukung")
+
+// tentukan format dari parameter eksplisit atau ekstensi file
+func DetectFormat(filename, explicit string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(explicit))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+
+	switch format {
+	case FormatCSV, FormatXLSX, FormatJSONL:
+		return format, nil
+	case "json-lines", "ndjson":
+		return FormatJSONL, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// gabung bahan jadi satu sel: "nasi|ayam|telur", "|" dan "\" di-escape dengan "\"
+func EncodeIngredients(ingredients []string) string {
+	escaped := make([]string, 0, len(ingredients))
+	for _, ing := range ingredients {
+		ing = strings.ReplaceAll(ing, `\`, `\\`)
+		ing = strings.ReplaceAll(ing, IngredientSeparator, `\`+IngredientSeparator)
+		escaped = append(escaped, ing)
+	}
+	return strings.Join(escaped, IngredientSeparator)
+}
+
+// kebalikan EncodeIngredients, bahan kosong dibuang
+func DecodeIngredients(cell string) []string {
+	var (
+		ingredients []string
+		current     strings.Builder
+		escaped     bool
+	)
+
+	flush := func() {
+		if ing := strings.TrimSpace(current.String()); ing != "" {
+			ingredients = append(ingredients, ing)
+		}
+		current.Reset()
+	}
+
+	for _, r := range cell {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case string(r) == IngredientSeparator:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return ingredients
+}
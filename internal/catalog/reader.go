@@ -0,0 +1,224 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// batas jumlah baris per file import
+const MaxImportRows = 5000
+
+// kolom wajib ada di header file import
+var requiredColumns = []string{"name", "category", "price", "ingredients"}
+
+// satu baris file import yang sudah di-parse
+type ImportRow struct {
+	Line       int
+	ExternalID string
+	Request    models.CreateMenuRequest
+	Errors     []string
+}
+
+// baca isi file jadi baris-baris sel (baris pertama = header)
+func ReadRows(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca CSV: %v", err)
+		}
+		if len(rows) > 0 && len(rows[0]) > 0 {
+			rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+		}
+		return rows, nil
+
+	case FormatXLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca XLSX: %v", err)
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("file XLSX tidak punya sheet")
+		}
+		rows, err := file.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca sheet %s: %v", sheets[0], err)
+		}
+		return rows, nil
+
+	case FormatJSONL:
+		return readJSONLRows(r)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// JSONL (satu object per baris) diubah ke bentuk tabel yang sama dengan CSV,
+// header = gabungan key sesuai urutan kemunculan
+func readJSONLRows(r io.Reader) ([][]string, error) {
+	var (
+		header  []string
+		index   = make(map[string]int)
+		objects []map[string]interface{}
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" {
+			continue
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			return nil, fmt.Errorf("gagal membaca JSONL baris %d: %v", line, err)
+		}
+		for key := range obj {
+			if _, ok := index[key]; !ok {
+				index[key] = len(header)
+				header = append(header, key)
+			}
+		}
+		objects = append(objects, obj)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca JSONL: %v", err)
+	}
+	if len(header) == 0 {
+		return nil, nil
+	}
+
+	rows := [][]string{header}
+	for _, obj := range objects {
+		row := make([]string, len(header))
+		for key, value := range obj {
+			row[index[key]] = jsonCell(value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func jsonCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return EncodeIngredients(items)
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// ubah baris sel jadi request create menu, error per baris dikumpulkan di Errors
+func ParseImportRows(rows [][]string) ([]ImportRow, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("file kosong, header tidak ditemukan")
+	}
+	if len(rows)-1 > MaxImportRows {
+		return nil, fmt.Errorf("maksimal %d baris per import", MaxImportRows)
+	}
+
+	header := make(map[string]int)
+	for i, col := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	var missing []string
+	for _, col := range requiredColumns {
+		if _, ok := header[col]; !ok {
+			missing = append(missing, col)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("kolom wajib tidak ada di header: %s", strings.Join(missing, ", "))
+	}
+
+	cell := func(row []string, col string) string {
+		idx, ok := header[col]
+		if !ok || idx >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[idx])
+	}
+
+	var parsed []ImportRow
+	for i, row := range rows[1:] {
+		if isBlankRow(row) {
+			continue
+		}
+
+		item := ImportRow{
+			Line:       i + 2,
+			ExternalID: cell(row, "external_id"),
+			Request: models.CreateMenuRequest{
+				Name:        cell(row, "name"),
+				Category:    strings.ToLower(cell(row, "category")),
+				Ingredients: DecodeIngredients(cell(row, "ingredients")),
+				Description: cell(row, "description"),
+			},
+		}
+
+		if v := cell(row, "price"); v != "" {
+			price, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				item.Errors = append(item.Errors, i18n.T(i18n.Default(), i18n.MsgImportPrice, v))
+			}
+			item.Request.Price = price
+		}
+		if v := cell(row, "calories"); v != "" {
+			calories, err := strconv.Atoi(v)
+			if err != nil {
+				item.Errors = append(item.Errors, i18n.T(i18n.Default(), i18n.MsgImportCalories, v))
+			} else {
+				item.Request.Calories = &calories
+			}
+		}
+		if item.ExternalID != "" {
+			item.Request.ExternalID = &item.ExternalID
+		}
+
+		parsed = append(parsed, item)
+	}
+	return parsed, nil
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,246 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/models"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// nama sheet hasil export XLSX
+const ExportSheet = "menus"
+
+// kolom yang boleh di-export
+var ExportColumns = []string{
+	"id", "external_id", "name", "category", "price", "calories",
+	"ingredients", "description", "view_count", "version", "created_at", "updated_at",
+}
+
+// kolom default, cukup untuk diimport ulang
+var DefaultExportColumns = []string{
+	"id", "external_id", "name", "category", "price", "calories", "ingredients", "description",
+}
+
+// validasi daftar kolom "a,b,c", kosong = kolom default
+func ParseColumns(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return DefaultExportColumns, nil
+	}
+
+	allowed := make(map[string]bool, len(ExportColumns))
+	for _, col := range ExportColumns {
+		allowed[col] = true
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	for _, col := range strings.Split(raw, ",") {
+		col = strings.ToLower(strings.TrimSpace(col))
+		if !allowed[col] {
+			return nil, fmt.Errorf("kolom '%s' tidak dikenal (tersedia: %s)", col, strings.Join(ExportColumns, ", "))
+		}
+		if !seen[col] {
+			seen[col] = true
+			columns = append(columns, col)
+		}
+	}
+	return columns, nil
+}
+
+// penulis export, satu menu per Write, Close wajib dipanggil di akhir
+type Writer interface {
+	Write(menu *models.Menu) error
+	Close() error
+}
+
+func NewWriter(w io.Writer, format string, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw, columns: columns}, nil
+
+	case FormatJSONL:
+		return &jsonlWriter{w: bufio.NewWriter(w), columns: columns}, nil
+
+	case FormatXLSX:
+		file := excelize.NewFile()
+		if err := file.SetSheetName("Sheet1", ExportSheet); err != nil {
+			return nil, err
+		}
+		// stream writer menyimpan baris ke file sementara kalau besar
+		stream, err := file.NewStreamWriter(ExportSheet)
+		if err != nil {
+			return nil, err
+		}
+		header := make([]interface{}, len(columns))
+		for i, col := range columns {
+			header[i] = col
+		}
+		if err := stream.SetRow("A1", header); err != nil {
+			return nil, err
+		}
+		return &xlsxWriter{out: w, file: file, stream: stream, columns: columns, row: 1}, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// nilai sel CSV/XLSX, ingredients pakai encoding yang sama dengan import
+func cellValue(menu *models.Menu, column string) string {
+	switch column {
+	case "id":
+		return strconv.FormatUint(uint64(menu.ID), 10)
+	case "external_id":
+		if menu.ExternalID == nil {
+			return ""
+		}
+		return *menu.ExternalID
+	case "name":
+		return menu.Name
+	case "category":
+		return menu.Category
+	case "price":
+		return strconv.FormatFloat(menu.Price, 'f', -1, 64)
+	case "calories":
+		if menu.Calories == nil {
+			return ""
+		}
+		return strconv.Itoa(*menu.Calories)
+	case "ingredients":
+		return EncodeIngredients(menu.Ingredients)
+	case "description":
+		return menu.Description
+	case "view_count":
+		return strconv.FormatInt(menu.ViewCount, 10)
+	case "version":
+		return strconv.FormatUint(uint64(menu.Version), 10)
+	case "created_at":
+		return menu.CreatedAt.Format(time.RFC3339)
+	case "updated_at":
+		return menu.UpdatedAt.Format(time.RFC3339)
+	}
+	return ""
+}
+
+// nilai JSON, ingredients tetap array
+func jsonValue(menu *models.Menu, column string) interface{} {
+	switch column {
+	case "id":
+		return menu.ID
+	case "external_id":
+		return menu.ExternalID
+	case "price":
+		return menu.Price
+	case "calories":
+		return menu.Calories
+	case "ingredients":
+		return []string(menu.Ingredients)
+	case "view_count":
+		return menu.ViewCount
+	case "version":
+		return menu.Version
+	case "created_at":
+		return menu.CreatedAt
+	case "updated_at":
+		return menu.UpdatedAt
+	}
+	return cellValue(menu, column)
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (c *csvWriter) Write(menu *models.Menu) error {
+	record := make([]string, len(c.columns))
+	for i, col := range c.columns {
+		record[i] = cellValue(menu, col)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func (j *jsonlWriter) Write(menu *models.Menu) error {
+	// urutan key mengikuti urutan kolom
+	var buf strings.Builder
+	buf.WriteByte('{')
+	for i, col := range j.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(col)
+		value, err := json.Marshal(jsonValue(menu, col))
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString("}\n")
+	_, err := j.w.WriteString(buf.String())
+	return err
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.w.Flush()
+}
+
+type xlsxWriter struct {
+	out     io.Writer
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	columns []string
+	row     int
+}
+
+func (x *xlsxWriter) Write(menu *models.Menu) error {
+	x.row++
+	values := make([]interface{}, len(x.columns))
+	for i, col := range x.columns {
+		switch col {
+		case "price", "view_count", "version", "id":
+			values[i] = jsonValue(menu, col)
+		case "calories":
+			if menu.Calories != nil {
+				values[i] = *menu.Calories
+			}
+		default:
+			values[i] = cellValue(menu, col)
+		}
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
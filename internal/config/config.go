@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +14,43 @@ type Config struct {
 	AppEnv      string
 	DatabaseURL string
 	GeminiAPIKey	string
+	EmbeddingProvider	string
+	EmbeddingDimensions	int
+	RequireIfMatch	bool
+	IdempotencyTTLHours	int
+	IdempotencyLockSeconds	int
+	MenuUniqueName	string
+	LegacyRoutes	bool
+	LegacyDeprecatedAt	string
+	LegacySunset	string
+	DefaultLanguage	string
+	GraphQLMaxDepth	int
+	GraphQLMaxComplexity	int
+	GRPCEnabled	bool
+	GRPCPort	string
+	EventLogSize	int
+	WebhookMaxAttempts	int
+	WebhookTimeoutSeconds	int
+	WebhookBackoffSeconds	int
+	WebhookBackoffMaxSeconds	int
+	WebhookAllowPrivateNetworks	bool
+	OutboxSinks	string
+	OutboxRetentionHours	int
+	OutboxMaxAttempts	int
+	NATSURL	string
+	NATSSubject	string
+	NATSJetStream	bool
+	AuthEnabled	bool
+	JWTAlgorithm	string
+	JWTSecret	string
+	JWTPrivateKeyFile	string
+	JWTPublicKeyFile	string
+	JWTIssuer	string
+	JWTAudience	string
+	JWTTTLMinutes	int
+	AuthBootstrapUsername	string
+	AuthBootstrapPassword	string
+	CORSAllowOrigins	string
 }
 
 var AppConfig *Config
@@ -27,6 +66,43 @@ func LoadConfig() {
 		AppEnv:      getEnv("APP_ENV", "development"),
 		DatabaseURL: getEnv("DATABASE_URL", ""),
 		GeminiAPIKey: getEnv("GEMINI_API_KEY", ""),
+		EmbeddingProvider: getEnv("EMBEDDING_PROVIDER", ""),
+		EmbeddingDimensions: getEnvInt("EMBEDDING_DIMENSIONS", 256),
+		RequireIfMatch: getEnvBool("REQUIRE_IF_MATCH", false),
+		IdempotencyTTLHours: getEnvInt("IDEMPOTENCY_TTL_HOURS", 24),
+		IdempotencyLockSeconds: getEnvInt("IDEMPOTENCY_LOCK_SECONDS", 60),
+		MenuUniqueName: strings.ToLower(strings.TrimSpace(getEnv("MENU_UNIQUE_NAME", "category"))),
+		LegacyRoutes: getEnvBool("LEGACY_ROUTES", true),
+		LegacyDeprecatedAt: getEnv("LEGACY_DEPRECATED_AT", "2026-10-19"),
+		LegacySunset: getEnv("LEGACY_SUNSET", "2027-04-30"),
+		DefaultLanguage: getEnv("DEFAULT_LANGUAGE", "id"),
+		GraphQLMaxDepth: getEnvInt("GRAPHQL_MAX_DEPTH", 10),
+		GraphQLMaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 1000),
+		GRPCEnabled: getEnvBool("GRPC_ENABLED", true),
+		GRPCPort: getEnv("GRPC_PORT", "9090"),
+		EventLogSize: getEnvInt("EVENT_LOG_SIZE", 1000),
+		WebhookMaxAttempts: getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookTimeoutSeconds: getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+		WebhookBackoffSeconds: getEnvInt("WEBHOOK_BACKOFF_SECONDS", 10),
+		WebhookBackoffMaxSeconds: getEnvInt("WEBHOOK_BACKOFF_MAX_SECONDS", 3600),
+		WebhookAllowPrivateNetworks: getEnvBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		OutboxSinks: getEnv("OUTBOX_SINKS", "broker,webhook"),
+		OutboxRetentionHours: getEnvInt("OUTBOX_RETENTION_HOURS", 24),
+		OutboxMaxAttempts: getEnvInt("OUTBOX_MAX_ATTEMPTS", 20),
+		NATSURL: getEnv("NATS_URL", "nats://localhost:4222"),
+		NATSSubject: getEnv("NATS_SUBJECT", "catalog"),
+		NATSJetStream: getEnvBool("NATS_JETSTREAM", false),
+		AuthEnabled: getEnvBool("AUTH_ENABLED", true),
+		JWTAlgorithm: getEnv("JWT_ALGORITHM", "HS256"),
+		JWTSecret: getEnv("JWT_SECRET", ""),
+		JWTPrivateKeyFile: getEnv("JWT_PRIVATE_KEY_FILE", ""),
+		JWTPublicKeyFile: getEnv("JWT_PUBLIC_KEY_FILE", ""),
+		JWTIssuer: getEnv("JWT_ISSUER", "menu-catalog-api"),
+		JWTAudience: getEnv("JWT_AUDIENCE", "menu-catalog-api"),
+		JWTTTLMinutes: getEnvInt("JWT_TTL_MINUTES", 60),
+		AuthBootstrapUsername: getEnv("AUTH_BOOTSTRAP_USERNAME", ""),
+		AuthBootstrapPassword: getEnv("AUTH_BOOTSTRAP_PASSWORD", ""),
+		CORSAllowOrigins: getEnv("CORS_ALLOW_ORIGINS", "http://localhost:3000"),
 	}
 
 	// validasi konfig
@@ -40,6 +116,13 @@ func LoadConfig() {
 		log.Fatal("DATABASE_URL environment variable is required")
 	}
 
+	// nilai lain tidak boleh diam-diam dianggap off, index unik nama menu bisa terhapus
+	switch AppConfig.MenuUniqueName {
+	case "category", "global", "off":
+	default:
+		log.Fatalf("MENU_UNIQUE_NAME tidak valid: %q (pilihan: category, global, off)", AppConfig.MenuUniqueName)
+	}
+
 	log.Printf("✅ Configuration loaded successfully")
 	log.Printf("   - Port: %s", AppConfig.Port)
 	log.Printf("   - Environment: %s", AppConfig.AppEnv)
@@ -54,6 +137,24 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// ngambil nilai env variabel berupa angka
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// ngambil nilai env variabel berupa boolean
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // GetConfig returns the application configuration
 func GetConfig() *Config {
 	return AppConfig
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/models"
 	"fmt"
 	"log"
 	"os"
@@ -65,6 +67,71 @@ func ConnectDatabase() {
 	log.Println("Koneksyen Berhasil!!!")
 }
 
+// sinkronisasi skema tabel
+func AutoMigrate() {
+	err := DB.AutoMigrate(
+		&models.Menu{},
+		&models.MenuEmbedding{},
+		&models.SearchLog{},
+		&models.IdempotencyRecord{},
+		&models.MenuVariant{},
+		&models.Tag{},
+		&models.MenuImage{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.WebhookDeliveryLog{},
+		&models.OutboxEvent{},
+		&models.User{},
+		&models.APIKey{},
+		&models.RoleAssignment{},
+	)
+	if err != nil {
+		log.Fatal("Gagal migrasi database:", err)
+	}
+
+	ensureMenuNameIndex(config.GetConfig().MenuUniqueName)
+}
+
+// nama index unik nama menu per cakupan
+var menuNameIndexes = map[string]string{
+	models.UniqueNameCategory: "CREATE UNIQUE INDEX IF NOT EXISTS idx_menus_unique_name_category ON menus (category, normalized_name)",
+	models.UniqueNameGlobal:   "CREATE UNIQUE INDEX IF NOT EXISTS idx_menus_unique_name_global ON menus (normalized_name)",
+}
+
+// isi normalized_name menu lama lalu pasang index unik sesuai MENU_UNIQUE_NAME,
+// index cakupan lain dihapus. Gagal bikin index (data lama sudah dobel) tidak fatal.
+// Cakupan yang tidak dikenal tidak mengubah index apa pun
+func ensureMenuNameIndex(scope string) {
+	if _, ok := menuNameIndexes[scope]; !ok && scope != models.UniqueNameOff {
+		log.Printf("MENU_UNIQUE_NAME %q tidak dikenal, index nama menu tidak diubah", scope)
+		return
+	}
+
+	err := DB.Exec(`UPDATE menus SET normalized_name = LOWER(REGEXP_REPLACE(TRIM(name), '\s+', ' ', 'g'))
+		WHERE normalized_name = ''`).Error
+	if err != nil {
+		log.Printf("Gagal mengisi normalized_name: %v", err)
+	}
+
+	for s := range menuNameIndexes {
+		if s == scope {
+			continue
+		}
+		if err := DB.Exec("DROP INDEX IF EXISTS idx_menus_unique_name_" + s).Error; err != nil {
+			log.Printf("Gagal menghapus index nama menu (%s): %v", s, err)
+		}
+	}
+
+	create, ok := menuNameIndexes[scope]
+	if !ok {
+		log.Println("Cek nama menu duplikat dimatikan (MENU_UNIQUE_NAME=off)")
+		return
+	}
+	if err := DB.Exec(create).Error; err != nil {
+		log.Printf("Gagal membuat index unik nama menu, cek data duplikat lewat GET /admin/duplicates: %v", err)
+	}
+}
+
 func GetDB() *gorm.DB {
 	return DB
 }
@@ -0,0 +1,64 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// HashEmbedder embedder lokal yang deterministik (feature hashing),
+// dipakai kalau Gemini tidak tersedia atau untuk testing offline
+type HashEmbedder struct {
+	dims int
+}
+
+// create HashEmbedder baru, dims default 256
+func NewHashEmbedder(dims int) *HashEmbedder {
+	if dims < 1 {
+		dims = 256
+	}
+	return &HashEmbedder{dims: dims}
+}
+
+func (e *HashEmbedder) Name() string {
+	return fmt.Sprintf("hash-%d", e.dims)
+}
+
+// embed teks: tiap kata + trigram karakter di-hash ke salah satu dimensi
+func (e *HashEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	vec := make([]float32, e.dims)
+
+	for _, token := range Tokenize(text) {
+		e.add(vec, "w:"+token, 1)
+
+		padded := "#" + token + "#"
+		runes := []rune(padded)
+		for i := 0; i+3 <= len(runes); i++ {
+			e.add(vec, "t:"+string(runes[i:i+3]), 0.5)
+		}
+	}
+
+	return normalize(vec), nil
+}
+
+func (e *HashEmbedder) add(vec []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	idx := int(sum % uint64(e.dims))
+	// bit paling atas nentuin tanda, biar collision saling meniadakan
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vec[idx] += weight
+}
+
+// pecah teks jadi token huruf/angka lowercase
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,80 @@
+package embedding
+
+import (
+	"sort"
+	"sync"
+)
+
+// hasil pencarian vektor
+type Match struct {
+	ID    uint
+	Score float64
+}
+
+// Index nyimpen vektor menu di memory (brute-force cosine)
+type Index struct {
+	mu      sync.RWMutex
+	vectors map[uint][]float32
+}
+
+func NewIndex() *Index {
+	return &Index{vectors: make(map[uint][]float32)}
+}
+
+// tambah / ganti vektor menu
+func (i *Index) Upsert(id uint, vec []float32) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.vectors[id] = vec
+}
+
+// hapus vektor menu
+func (i *Index) Delete(id uint) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.vectors, id)
+}
+
+// cek menu sudah ada di index
+func (i *Index) Has(id uint) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	_, ok := i.vectors[id]
+	return ok
+}
+
+// ambil vektor menu
+func (i *Index) Get(id uint) ([]float32, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	vec, ok := i.vectors[id]
+	return vec, ok
+}
+
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.vectors)
+}
+
+// cari k menu paling mirip dengan query
+func (i *Index) Search(query []float32, k int) []Match {
+	i.mu.RLock()
+	matches := make([]Match, 0, len(i.vectors))
+	for id, vec := range i.vectors {
+		matches = append(matches, Match{ID: id, Score: Cosine(query, vec)})
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Score == matches[b].Score {
+			return matches[a].ID < matches[b].ID
+		}
+		return matches[a].Score > matches[b].Score
+	})
+
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
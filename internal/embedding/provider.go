@@ -0,0 +1,66 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"GDGOC-API/internal/models"
+)
+
+// Provider menghasilkan vektor embedding dari teks
+type Provider interface {
+	// nama provider, disimpan bareng vektor supaya bisa deteksi perlu re-embed
+	Name() string
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// gabungin field menu jadi satu teks buat di-embed
+func MenuText(menu models.Menu) string {
+	parts := []string{menu.Name, menu.Category}
+	if menu.Description != "" {
+		parts = append(parts, menu.Description)
+	}
+	if len(menu.Ingredients) > 0 {
+		parts = append(parts, "bahan: "+strings.Join(menu.Ingredients, ", "))
+	}
+	if menu.Calories != nil {
+		parts = append(parts, fmt.Sprintf("%d kalori", *menu.Calories))
+	}
+	return strings.Join(parts, ". ")
+}
+
+// cosine similarity antara dua vektor
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// normalisasi vektor ke panjang 1
+func normalize(vec []float32) []float32 {
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vec
+	}
+	norm = math.Sqrt(norm)
+	for i := range vec {
+		vec[i] = float32(float64(vec[i]) / norm)
+	}
+	return vec
+}
@@ -0,0 +1,233 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GDGOC-API/internal/models"
+)
+
+// jenis perubahan katalog menu
+type Type string
+
+const (
+	MenuCreated Type = "menu.created"
+	MenuUpdated Type = "menu.updated"
+	MenuDeleted Type = "menu.deleted"
+)
+
+// satu perubahan katalog. ID di feed broker (SSE/WebSocket/gRPC) naik berurutan per proses
+// dan Cursor = posisi resume "<epoch>-<ID>", di sink lain (webhook, NATS, log) ID = id outbox
+// yang tetap sama saat event dikirim ulang dan Cursor kosong
+type Event struct {
+	ID         uint64       `json:"id"`
+	Cursor     string       `json:"cursor,omitempty"`
+	Type       Type         `json:"type"`
+	MenuID     uint         `json:"menu_id"`
+	Version    uint         `json:"version"`
+	Menu       *models.Menu `json:"menu,omitempty"`
+	OccurredAt time.Time    `json:"occurred_at"`
+}
+
+// posisi di feed broker. Epoch = boot id acak per broker, berbeda setiap proses
+// start ulang (juga restart dalam detik yang sama) sehingga ID dari proses sebelumnya
+// tidak tertukar dengan ID baru
+type Cursor struct {
+	Epoch int64
+	Seq   uint64
+}
+
+var ErrInvalidCursor = errors.New("format cursor event harus <epoch>-<id>")
+
+func (c Cursor) String() string {
+	return strconv.FormatInt(c.Epoch, 10) + "-" + strconv.FormatUint(c.Seq, 10)
+}
+
+// parse "<epoch>-<id>". ID angka saja (format lama, tanpa epoch) diterima dengan
+// Epoch 0 supaya client lama mendapat reset, bukan error
+func ParseCursor(s string) (Cursor, error) {
+	epoch, seq, found := strings.Cut(s, "-")
+	if !found {
+		epoch, seq = "0", s
+	}
+	e, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil || e < 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{Epoch: e, Seq: n}, nil
+}
+
+// ukuran buffer default per subscriber
+const DefaultBuffer = 256
+
+// broker in-memory untuk perubahan katalog. Publish tidak pernah menunggu:
+// subscriber yang buffernya penuh diputus (channel ditutup, Lagged = true)
+// supaya bisa subscribe ulang dan ambil data terbaru, bukan diam-diam kehilangan event.
+// Event terakhir disimpan di log terbatas supaya client bisa melanjutkan (Resume)
+type Broker struct {
+	mu          sync.Mutex
+	epoch       int64
+	lastID      uint64
+	log         []Event
+	logSize     int
+	subscribers map[*Subscription]struct{}
+}
+
+// logSize = jumlah event terakhir yang disimpan untuk Resume, 0 = tanpa log
+func NewBroker(logSize int) *Broker {
+	if logSize < 0 {
+		logSize = 0
+	}
+	return &Broker{
+		epoch:       newEpoch(),
+		log:         make([]Event, 0, logSize),
+		logSize:     logSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// boot id acak 63 bit, selalu positif supaya format "<epoch>-<id>" tetap bisa di-parse
+func newEpoch() int64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	if epoch := int64(binary.BigEndian.Uint64(b[:]) >> 1); epoch > 0 {
+		return epoch
+	}
+	return time.Now().UnixNano()
+}
+
+// kirim event ke semua subscriber dengan ID urutan feed berikutnya,
+// menu disalin supaya aman dibaca goroutine lain
+func (b *Broker) Publish(event Event) Event {
+	if event.Menu != nil {
+		snapshot := *event.Menu
+		event.Menu = &snapshot
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	event.Cursor = b.cursor(b.lastID).String()
+	b.append(event)
+
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			sub.lagged = true
+			b.remove(sub)
+		}
+	}
+	return event
+}
+
+// subscriber baru mulai dari event berikutnya, buffer <= 0 memakai DefaultBuffer. Close wajib dipanggil
+func (b *Broker) Subscribe(buffer int) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe(buffer)
+}
+
+// subscriber yang melanjutkan setelah event last: event yang terlewat dari log
+// dikembalikan di backlog, event berikutnya lewat channel. ok false kalau sebagian
+// event sudah keluar dari log atau last tidak dikenal (epoch lain = dari proses
+// sebelum restart), client perlu ambil ulang data lalu lanjut dari sub.StartCursor()
+func (b *Broker) Resume(last Cursor, buffer int) (sub *Subscription, backlog []Event, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub = b.subscribe(buffer)
+	lastID := last.Seq
+	switch {
+	case last.Epoch != b.epoch:
+		return sub, nil, false
+	case lastID == b.lastID:
+		return sub, nil, true
+	case lastID > b.lastID || len(b.log) == 0 || lastID+1 < b.log[0].ID:
+		return sub, nil, false
+	}
+	for _, event := range b.log {
+		if event.ID > lastID {
+			backlog = append(backlog, event)
+		}
+	}
+	return sub, backlog, true
+}
+
+// dipanggil dengan mu terkunci
+func (b *Broker) subscribe(buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	ch := make(chan Event, buffer)
+	sub := &Subscription{C: ch, ch: ch, broker: b, start: b.cursor(b.lastID)}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (b *Broker) cursor(id uint64) Cursor {
+	return Cursor{Epoch: b.epoch, Seq: id}
+}
+
+// simpan event ke log, event terlama dibuang kalau log penuh. Dipanggil dengan mu terkunci
+func (b *Broker) append(event Event) {
+	if b.logSize == 0 {
+		return
+	}
+	if len(b.log) == b.logSize {
+		copy(b.log, b.log[1:])
+		b.log = b.log[:len(b.log)-1]
+	}
+	b.log = append(b.log, event)
+}
+
+// dipanggil dengan mu terkunci
+func (b *Broker) remove(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+type Subscription struct {
+	// event masuk, ditutup saat Close atau saat subscriber tertinggal
+	C <-chan Event
+
+	ch     chan Event
+	broker *Broker
+	lagged bool
+	start  Cursor
+}
+
+// posisi event terakhir saat subscribe, event di channel selalu setelah posisi ini
+func (s *Subscription) StartCursor() Cursor {
+	return s.start
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	s.broker.remove(s)
+	s.broker.mu.Unlock()
+}
+
+// true kalau subscription diputus karena buffer penuh
+func (s *Subscription) Lagged() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.lagged
+}
@@ -0,0 +1,86 @@
+package events
+
+import "testing"
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Cursor
+		wantErr bool
+	}{
+		{in: "1792396800-42", want: Cursor{Epoch: 1792396800, Seq: 42}},
+		{in: "42", want: Cursor{Epoch: 0, Seq: 42}},
+		{in: "abc", wantErr: true},
+		{in: "1792396800-", wantErr: true},
+		{in: "-42", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseCursor(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCursor(%q) harus gagal", tt.in)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseCursor(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+			}
+			if tt.want.Epoch != 0 && got.String() != tt.in {
+				t.Fatalf("String() = %q, want %q", got.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	b := NewBroker(2)
+	var published []Event
+	for i := 0; i < 3; i++ {
+		published = append(published, b.Publish(Event{Type: MenuUpdated}))
+	}
+	cursor := func(e Event) Cursor {
+		c, err := ParseCursor(e.Cursor)
+		if err != nil {
+			t.Fatalf("cursor event %q: %v", e.Cursor, err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name        string
+		last        Cursor
+		wantOK      bool
+		wantBacklog int
+	}{
+		{name: "event terakhir", last: cursor(published[2]), wantOK: true},
+		{name: "event masih di log", last: cursor(published[1]), wantOK: true, wantBacklog: 1},
+		{name: "event sudah keluar dari log", last: Cursor{Epoch: b.epoch, Seq: 0}, wantOK: false},
+		{name: "epoch proses sebelumnya", last: Cursor{Epoch: b.epoch - 1, Seq: 3}, wantOK: false},
+		{name: "ID tanpa epoch", last: Cursor{Seq: 3}, wantOK: false},
+		{name: "ID di masa depan", last: Cursor{Epoch: b.epoch, Seq: 10}, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, backlog, ok := b.Resume(tt.last, 0)
+			defer sub.Close()
+			if ok != tt.wantOK || len(backlog) != tt.wantBacklog {
+				t.Fatalf("Resume(%v) ok=%v backlog=%d; want ok=%v backlog=%d", tt.last, ok, len(backlog), tt.wantOK, tt.wantBacklog)
+			}
+			if sub.StartCursor() != cursor(published[2]) {
+				t.Fatalf("StartCursor() = %v, want %s", sub.StartCursor(), published[2].Cursor)
+			}
+		})
+	}
+}
+
+func TestBrokerEpochUnique(t *testing.T) {
+	seen := make(map[int64]bool)
+	for i := 0; i < 100; i++ {
+		epoch := NewBroker(0).epoch
+		if epoch <= 0 || seen[epoch] {
+			t.Fatalf("epoch %d tidak unik atau tidak positif", epoch)
+		}
+		seen[epoch] = true
+	}
+}
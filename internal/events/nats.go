@@ -0,0 +1,150 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// kirim event ke server NATS ke subject "<prefix>.<type>", mis. catalog.menu.updated.
+// Header Nats-Msg-Id berisi id event supaya JetStream bisa membuang duplikat.
+// jetStream true = publish lewat JetStream dan baru dianggap berhasil setelah ack stream,
+// false = core NATS, dianggap berhasil setelah server membalas flush
+type NATSSink struct {
+	url       string
+	prefix    string
+	jetStream bool
+	timeout   time.Duration
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+// rawURL: satu atau beberapa URL server dipisah koma (nats://, tls://). Koneksi dibuka
+// saat publish pertama, setelah itu reconnect ditangani client NATS
+func NewNATSSink(rawURL, prefix string, jetStream bool, timeout time.Duration) (*NATSSink, error) {
+	var servers []string
+	for _, server := range strings.Split(rawURL, ",") {
+		server = strings.TrimSpace(server)
+		if server == "" {
+			continue
+		}
+		if !strings.Contains(server, "://") {
+			server = "nats://" + server
+		}
+		u, err := url.Parse(server)
+		if err != nil {
+			return nil, err
+		}
+		switch u.Scheme {
+		case "nats", "tls":
+		default:
+			return nil, fmt.Errorf("skema URL NATS tidak didukung: %s", u.Scheme)
+		}
+		if u.Hostname() == "" {
+			return nil, errors.New("host NATS kosong")
+		}
+		servers = append(servers, server)
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("URL NATS kosong")
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &NATSSink{
+		url:       strings.Join(servers, ","),
+		prefix:    strings.Trim(prefix, "."),
+		jetStream: jetStream,
+		timeout:   timeout,
+	}, nil
+}
+
+func (s *NATSSink) Name() string {
+	return "nats"
+}
+
+func (s *NATSSink) Subject(typ Type) string {
+	if s.prefix == "" {
+		return string(typ)
+	}
+	return s.prefix + "." + string(typ)
+}
+
+func (s *NATSSink) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	msg := nats.NewMsg(s.Subject(event.Type))
+	msg.Data = payload
+	msg.Header.Set(jetstream.MsgIDHeader, fmt.Sprintf("menu-catalog-%d", event.ID))
+
+	conn, js, err := s.connection()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	if js != nil {
+		_, err := js.PublishMsg(ctx, msg)
+		return err
+	}
+	// server lama tanpa dukungan header: kirim tanpa Nats-Msg-Id
+	if !conn.HeadersSupported() {
+		msg.Header = nil
+	}
+	if err := conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	return conn.FlushWithContext(ctx)
+}
+
+// tutup koneksi, pesan yang masih di buffer dikirim dulu
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Drain()
+	s.conn, s.js = nil, nil
+	return err
+}
+
+// koneksi dibuka sekali, gagal connect dicoba lagi di publish berikutnya
+func (s *NATSSink) connection() (*nats.Conn, jetstream.JetStream, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		return s.conn, s.js, nil
+	}
+
+	conn, err := nats.Connect(s.url,
+		nats.Name("menu-catalog-api"),
+		nats.Timeout(s.timeout),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	var js jetstream.JetStream
+	if s.jetStream {
+		if js, err = jetstream.New(conn); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+	}
+	s.conn, s.js = conn, js
+	return conn, js, nil
+}
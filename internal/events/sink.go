@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+)
+
+// tujuan event dari relay outbox. Pengiriman at-least-once: event yang gagal di satu
+// sink dicoba ulang, jadi sink (atau penerimanya) perlu tahan event yang sama terkirim
+// lebih dari sekali (deduplikasi lewat Event.ID)
+type Sink interface {
+	// nama unik sink, dicatat di outbox supaya sink yang sudah berhasil tidak dikirim ulang
+	Name() string
+	Publish(ctx context.Context, event Event) error
+}
+
+// teruskan event ke broker in-memory (feed SSE/WebSocket & gRPC WatchCatalog)
+func NewBrokerSink(broker *Broker) Sink {
+	return brokerSink{broker: broker}
+}
+
+type brokerSink struct {
+	broker *Broker
+}
+
+func (brokerSink) Name() string {
+	return "broker"
+}
+
+func (s brokerSink) Publish(ctx context.Context, event Event) error {
+	s.broker.Publish(event)
+	return nil
+}
+
+// tulis event sebagai satu baris JSON ke logger, logger nil = log standar
+func NewLogSink(logger *log.Logger) Sink {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return logSink{logger: logger}
+}
+
+type logSink struct {
+	logger *log.Logger
+}
+
+func (logSink) Name() string {
+	return "log"
+}
+
+func (s logSink) Publish(ctx context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.logger.Printf("catalog event: %s", line)
+	return nil
+}
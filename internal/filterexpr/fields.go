@@ -0,0 +1,114 @@
+package filterexpr
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+type FieldType int
+
+const (
+	TypeString FieldType = iota
+	TypeNumber
+	TypeInteger
+	TypeTime
+	TypeArray
+)
+
+// literal tanggal tanpa jam (YYYY-MM-DD) = satu hari penuh [Start, End) di Location
+type Date struct {
+	Start time.Time
+	End   time.Time
+}
+
+// zona waktu literal tanggal, sama dengan zona waktu aplikasi
+var Location = loadLocation()
+
+func loadLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.FixedZone("Asia/Jakarta", 7*60*60)
+	}
+	return loc
+}
+
+// field yang boleh dipakai di filter beserta kolom db-nya
+type Field struct {
+	Column   string
+	Type     FieldType
+	Nullable bool
+}
+
+// allow-list field filter
+var Fields = map[string]Field{
+	"name":        {Column: "name", Type: TypeString},
+	"category":    {Column: "category", Type: TypeString},
+	"description": {Column: "description", Type: TypeString},
+	"price":       {Column: "price", Type: TypeNumber},
+	"calories":    {Column: "calories", Type: TypeInteger, Nullable: true},
+	"ingredients": {Column: "ingredients", Type: TypeArray},
+	"created_at":  {Column: "created_at", Type: TypeTime},
+	"updated_at":  {Column: "updated_at", Type: TypeTime},
+}
+
+// cek operator cocok dengan tipe field
+func (f Field) allows(op string) error {
+	switch op {
+	case "isnull", "notnull":
+		if !f.Nullable {
+			return errors.New("field tidak bisa null")
+		}
+		return nil
+	case "has":
+		if f.Type != TypeArray {
+			return errors.New("operator 'has' hanya untuk ingredients")
+		}
+		return nil
+	case "contains":
+		if f.Type != TypeString {
+			return errors.New("operator 'contains' hanya untuk field teks")
+		}
+		return nil
+	}
+
+	if f.Type == TypeArray {
+		return errors.New("ingredients hanya mendukung operator 'has'")
+	}
+	switch op {
+	case "<", "<=", ">", ">=", "between":
+		if f.Type == TypeString {
+			return errors.New("operator '" + op + "' tidak didukung untuk field teks")
+		}
+	}
+	return nil
+}
+
+// konversi literal ke tipe field
+func (f Field) convert(raw string, pos int) (interface{}, error) {
+	switch f.Type {
+	case TypeNumber:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errorf(pos, "'%s' bukan angka", raw)
+		}
+		return v, nil
+	case TypeInteger:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, errorf(pos, "'%s' bukan bilangan bulat", raw)
+		}
+		return v, nil
+	case TypeTime:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		t, err := time.ParseInLocation("2006-01-02", raw, Location)
+		if err != nil {
+			return nil, errorf(pos, "'%s' bukan tanggal (YYYY-MM-DD atau RFC3339)", raw)
+		}
+		return Date{Start: t, End: t.AddDate(0, 0, 1)}, nil
+	default:
+		return raw, nil
+	}
+}
@@ -0,0 +1,131 @@
+package filterexpr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+// error parsing/validasi filter, Pos = posisi karakter (mulai 1)
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	if e.Pos > 0 {
+		return fmt.Sprintf("filter: posisi %d: %s", e.Pos, e.Msg)
+	}
+	return "filter: " + e.Msg
+}
+
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// pecah filter jadi token
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+
+		case r == '"' || r == '\'':
+			quote := r
+			var sb strings.Builder
+			j := i + 1
+			closed := false
+			for j < len(runes) {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					sb.WriteRune(runes[j+1])
+					j += 2
+					continue
+				}
+				if runes[j] == quote {
+					closed = true
+					break
+				}
+				sb.WriteRune(runes[j])
+				j++
+			}
+			if !closed {
+				return nil, errorf(pos, "string tidak ditutup")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[i : j+1]), value: sb.String(), pos: pos})
+			i = j + 1
+
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, errorf(pos, "operator tidak dikenal '!'")
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, value: op, pos: pos})
+			i += len(op)
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == '-' || runes[j] == ':' || runes[j] == 'T' || runes[j] == 'Z' || runes[j] == '+') {
+				j++
+			}
+			text := string(runes[i:j])
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: text, pos: pos})
+			i = j
+
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '-') {
+				j++
+			}
+			text := string(runes[i:j])
+			tokens = append(tokens, token{kind: tokenIdent, text: text, value: strings.ToLower(text), pos: pos})
+			i = j
+
+		default:
+			return nil, errorf(pos, "karakter tidak dikenal '%c'", r)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes) + 1})
+	return tokens, nil
+}
@@ -0,0 +1,290 @@
+package filterexpr
+
+// batas ukuran filter supaya query tidak kebablasan
+const (
+	MaxLength     = 1000
+	MaxConditions = 20
+	MaxDepth      = 8
+)
+
+// Node elemen AST filter
+type Node interface {
+	node()
+}
+
+// gabungan kondisi and / or
+type Logical struct {
+	Op    string // "and" | "or"
+	Left  Node
+	Right Node
+}
+
+type Not struct {
+	Expr Node
+}
+
+// satu kondisi field, Values sudah dikonversi sesuai tipe field
+type Comparison struct {
+	Field  string
+	Op     string // = != < <= > >= in notin between has contains isnull notnull
+	Values []interface{}
+	Pos    int
+}
+
+func (Logical) node()    {}
+func (Not) node()        {}
+func (Comparison) node() {}
+
+type parser struct {
+	tokens     []token
+	pos        int
+	conditions int
+}
+
+// parse & validasi filter jadi AST
+func Parse(input string) (Node, error) {
+	if len(input) > MaxLength {
+		return nil, errorf(0, "filter maksimal %d karakter", MaxLength)
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.pos, "token tidak terduga '%s'", tok.text)
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isKeyword(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && tok.value == word
+}
+
+func (p *parser) expectKeyword(word string) error {
+	tok := p.next()
+	if tok.kind != tokenIdent || tok.value != word {
+		return errorf(tok.pos, "diharapkan '%s'", word)
+	}
+	return nil
+}
+
+func (p *parser) parseOr(depth int) (Node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (Node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary(depth int) (Node, error) {
+	if depth > MaxDepth {
+		return nil, errorf(p.peek().pos, "filter terlalu dalam (maksimal %d level)", MaxDepth)
+	}
+
+	if p.isKeyword("not") {
+		p.next()
+		expr, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	}
+
+	if p.peek().kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, errorf(tok.pos, "diharapkan ')'")
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenIdent {
+		return nil, errorf(fieldTok.pos, "diharapkan nama field")
+	}
+	field, ok := Fields[fieldTok.value]
+	if !ok {
+		return nil, errorf(fieldTok.pos, "field '%s' tidak diizinkan", fieldTok.text)
+	}
+
+	p.conditions++
+	if p.conditions > MaxConditions {
+		return nil, errorf(fieldTok.pos, "maksimal %d kondisi", MaxConditions)
+	}
+
+	cmp := Comparison{Field: fieldTok.value, Pos: fieldTok.pos}
+	opTok := p.next()
+
+	switch {
+	case opTok.kind == tokenOperator:
+		cmp.Op = opTok.value
+		v, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+		cmp.Values = []interface{}{v}
+
+	case opTok.kind == tokenIdent && (opTok.value == "in" || opTok.value == "not"):
+		cmp.Op = "in"
+		if opTok.value == "not" {
+			if err := p.expectKeyword("in"); err != nil {
+				return nil, err
+			}
+			cmp.Op = "notin"
+		}
+		values, err := p.parseList(field)
+		if err != nil {
+			return nil, err
+		}
+		cmp.Values = values
+
+	case opTok.kind == tokenIdent && opTok.value == "between":
+		cmp.Op = "between"
+		low, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("and"); err != nil {
+			return nil, err
+		}
+		high, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+		cmp.Values = []interface{}{low, high}
+
+	case opTok.kind == tokenIdent && (opTok.value == "has" || opTok.value == "contains"):
+		cmp.Op = opTok.value
+		valTok := p.next()
+		if valTok.kind != tokenString && valTok.kind != tokenIdent {
+			return nil, errorf(valTok.pos, "diharapkan teks")
+		}
+		text := valTok.value
+		if valTok.kind == tokenIdent {
+			text = valTok.text
+		}
+		cmp.Values = []interface{}{text}
+
+	case opTok.kind == tokenIdent && opTok.value == "is":
+		cmp.Op = "isnull"
+		if p.isKeyword("not") {
+			p.next()
+			cmp.Op = "notnull"
+		}
+		if err := p.expectKeyword("null"); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, errorf(opTok.pos, "diharapkan operator setelah '%s'", fieldTok.text)
+	}
+
+	if err := field.allows(cmp.Op); err != nil {
+		return nil, errorf(opTok.pos, "%s", err.Error())
+	}
+	return cmp, nil
+}
+
+// daftar nilai dalam kurung: (a, b, c)
+func (p *parser) parseList(field Field) ([]interface{}, error) {
+	if tok := p.next(); tok.kind != tokenLParen {
+		return nil, errorf(tok.pos, "diharapkan '('")
+	}
+
+	var values []interface{}
+	for {
+		v, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		tok := p.next()
+		if tok.kind == tokenRParen {
+			break
+		}
+		if tok.kind != tokenComma {
+			return nil, errorf(tok.pos, "diharapkan ',' atau ')'")
+		}
+	}
+
+	if len(values) > MaxConditions {
+		return nil, errorf(0, "maksimal %d nilai dalam in (...)", MaxConditions)
+	}
+	return values, nil
+}
+
+func (p *parser) parseValue(field Field) (interface{}, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString, tokenNumber:
+		return field.convert(tok.value, tok.pos)
+	case tokenIdent:
+		if isReserved(tok.value) {
+			return nil, errorf(tok.pos, "diharapkan nilai, bukan '%s'", tok.text)
+		}
+		return field.convert(tok.text, tok.pos)
+	default:
+		return nil, errorf(tok.pos, "diharapkan nilai")
+	}
+}
+
+func isReserved(word string) bool {
+	switch word {
+	case "and", "or", "not", "in", "between", "has", "contains", "is", "null":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package filterexpr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "sama dengan",
+			input:    `category = foods`,
+			wantSQL:  "category = ?",
+			wantArgs: []interface{}{"foods"},
+		},
+		{
+			name:     "tidak sama dengan & angka",
+			input:    `price != 15000`,
+			wantSQL:  "price <> ?",
+			wantArgs: []interface{}{15000.0},
+		},
+		{
+			name:     "prioritas and di atas or",
+			input:    `category = foods or category = drinks and price < 10000`,
+			wantSQL:  "(category = ? OR (category = ? AND price < ?))",
+			wantArgs: []interface{}{"foods", "drinks", 10000.0},
+		},
+		{
+			name:     "kurung",
+			input:    `(category = foods or category = drinks) and price <= 10000`,
+			wantSQL:  "((category = ? OR category = ?) AND price <= ?)",
+			wantArgs: []interface{}{"foods", "drinks", 10000.0},
+		},
+		{
+			name:     "not",
+			input:    `not category = drinks`,
+			wantSQL:  "NOT (category = ?)",
+			wantArgs: []interface{}{"drinks"},
+		},
+		{
+			name:     "in",
+			input:    `category in (foods, "snacks")`,
+			wantSQL:  "category IN ?",
+			wantArgs: []interface{}{[]interface{}{"foods", "snacks"}},
+		},
+		{
+			name:     "not in",
+			input:    `category not in (drinks)`,
+			wantSQL:  "category NOT IN ?",
+			wantArgs: []interface{}{[]interface{}{"drinks"}},
+		},
+		{
+			name:     "between integer",
+			input:    `calories between 200 and 500`,
+			wantSQL:  "calories BETWEEN ? AND ?",
+			wantArgs: []interface{}{int64(200), int64(500)},
+		},
+		{
+			name:     "has",
+			input:    `ingredients has "ayam"`,
+			wantSQL:  "EXISTS (SELECT 1 FROM unnest(ingredients) AS ing WHERE LOWER(ing) = LOWER(?))",
+			wantArgs: []interface{}{"ayam"},
+		},
+		{
+			name:     "contains di-escape",
+			input:    `name contains "50%_Off"`,
+			wantSQL:  `LOWER(name) LIKE ? ESCAPE '\'`,
+			wantArgs: []interface{}{`%50\%\_off%`},
+		},
+		{
+			name:    "is null",
+			input:   `calories is null`,
+			wantSQL: "calories IS NULL",
+		},
+		{
+			name:    "is not null",
+			input:   `calories is not null`,
+			wantSQL: "calories IS NOT NULL",
+		},
+		{
+			name:     "tanggal >=",
+			input:    `created_at >= 2026-10-01`,
+			wantSQL:  "created_at >= ?",
+			wantArgs: []interface{}{day(2026, 10, 1)},
+		},
+		{
+			name:     "tanggal <= sampai akhir hari",
+			input:    `created_at <= 2026-10-31`,
+			wantSQL:  "created_at < ?",
+			wantArgs: []interface{}{day(2026, 11, 1)},
+		},
+		{
+			name:     "tanggal >",
+			input:    `created_at > 2026-10-31`,
+			wantSQL:  "created_at >= ?",
+			wantArgs: []interface{}{day(2026, 11, 1)},
+		},
+		{
+			name:     "tanggal = satu hari penuh",
+			input:    `updated_at = 2026-10-05`,
+			wantSQL:  "(updated_at >= ? AND updated_at < ?)",
+			wantArgs: []interface{}{day(2026, 10, 5), day(2026, 10, 6)},
+		},
+		{
+			name:     "tanggal != di luar hari itu",
+			input:    `updated_at != 2026-10-05`,
+			wantSQL:  "(updated_at < ? OR updated_at >= ?)",
+			wantArgs: []interface{}{day(2026, 10, 5), day(2026, 10, 6)},
+		},
+		{
+			name:     "between tanggal termasuk hari terakhir",
+			input:    `created_at between 2026-10-01 and 2026-10-31`,
+			wantSQL:  "(created_at >= ? AND created_at < ?)",
+			wantArgs: []interface{}{day(2026, 10, 1), day(2026, 11, 1)},
+		},
+		{
+			name:     "between tanggal & RFC3339",
+			input:    `created_at between 2026-10-01 and 2026-10-31T12:00:00Z`,
+			wantSQL:  "(created_at >= ? AND created_at <= ?)",
+			wantArgs: []interface{}{day(2026, 10, 1), time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "in tanggal",
+			input:    `created_at in (2026-10-01, 2026-10-03)`,
+			wantSQL:  "((created_at >= ? AND created_at < ?) OR (created_at >= ? AND created_at < ?))",
+			wantArgs: []interface{}{day(2026, 10, 1), day(2026, 10, 2), day(2026, 10, 3), day(2026, 10, 4)},
+		},
+		{
+			name:     "RFC3339",
+			input:    `updated_at < 2026-10-01T08:00:00Z`,
+			wantSQL:  "updated_at < ?",
+			wantArgs: []interface{}{time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "keyword & field tidak case sensitive",
+			input:    `Category = "Foods" AND Price > 1`,
+			wantSQL:  "(category = ? AND price > ?)",
+			wantArgs: []interface{}{"Foods", 1.0},
+		},
+		{
+			name:     "escape dalam string",
+			input:    `name = 'Kopi \'Susu\''`,
+			wantSQL:  "name = ?",
+			wantArgs: []interface{}{"Kopi 'Susu'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := Compile(tt.input)
+			if err != nil {
+				t.Fatalf("Compile(%q): %v", tt.input, err)
+			}
+			if sql != tt.wantSQL {
+				t.Fatalf("sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tooMany := strings.TrimSuffix(strings.Repeat("price > 1 and ", MaxConditions+1), " and ")
+	tooDeep := strings.Repeat("(", MaxDepth+2) + "price > 1" + strings.Repeat(")", MaxDepth+2)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantPos int
+		wantMsg string
+	}{
+		{name: "field tidak diizinkan", input: `password = x`, wantPos: 1, wantMsg: "tidak diizinkan"},
+		{name: "injeksi nama kolom", input: `price; drop table menus`, wantPos: 6, wantMsg: "karakter tidak dikenal"},
+		{name: "string tidak ditutup", input: `name = "abc`, wantPos: 8, wantMsg: "tidak ditutup"},
+		{name: "bukan angka", input: `price > murah`, wantPos: 9, wantMsg: "bukan angka"},
+		{name: "bukan bilangan bulat", input: `calories = 1.5`, wantPos: 12, wantMsg: "bukan bilangan bulat"},
+		{name: "bukan tanggal", input: `created_at > 2026-13-45`, wantPos: 14, wantMsg: "bukan tanggal"},
+		{name: "operator tidak cocok tipe", input: `name > a`, wantPos: 6, wantMsg: "tidak didukung untuk field teks"},
+		{name: "has selain ingredients", input: `name has a`, wantPos: 6, wantMsg: "hanya untuk ingredients"},
+		{name: "ingredients selain has", input: `ingredients = ayam`, wantPos: 13, wantMsg: "hanya mendukung operator 'has'"},
+		{name: "is null field wajib", input: `price is null`, wantPos: 7, wantMsg: "tidak bisa null"},
+		{name: "kurung tidak ditutup", input: `(price > 1`, wantPos: 11, wantMsg: "diharapkan ')'"},
+		{name: "token sisa", input: `price > 1 price`, wantPos: 11, wantMsg: "token tidak terduga"},
+		{name: "nilai keyword", input: `category = and`, wantPos: 12, wantMsg: "bukan 'and'"},
+		{name: "between tanpa and", input: `price between 1 or 2`, wantPos: 17, wantMsg: "diharapkan 'and'"},
+		{name: "operator !", input: `price ! 1`, wantPos: 7, wantMsg: "operator tidak dikenal"},
+		{name: "kosong", input: ``, wantPos: 1, wantMsg: "diharapkan nama field"},
+		{name: "terlalu panjang", input: strings.Repeat("a", MaxLength+1), wantMsg: "maksimal"},
+		{name: "terlalu banyak kondisi", input: tooMany, wantMsg: "kondisi"},
+		{name: "terlalu dalam", input: tooDeep, wantMsg: "terlalu dalam"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var ferr *Error
+			if !errors.As(err, &ferr) {
+				t.Fatalf("Parse(%q) error = %v, want *Error", tt.input, err)
+			}
+			if tt.wantPos != 0 && ferr.Pos != tt.wantPos {
+				t.Fatalf("Pos = %d, want %d (%v)", ferr.Pos, tt.wantPos, ferr)
+			}
+			if !strings.Contains(ferr.Msg, tt.wantMsg) {
+				t.Fatalf("Msg = %q, want mengandung %q", ferr.Msg, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// awal hari di zona waktu aplikasi
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, Location)
+}
@@ -0,0 +1,125 @@
+package filterexpr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// terjemahkan AST ke klausa WHERE berparameter, nama kolom selalu dari allow-list
+func ToSQL(node Node) (string, []interface{}) {
+	switch n := node.(type) {
+	case Logical:
+		left, leftArgs := ToSQL(n.Left)
+		right, rightArgs := ToSQL(n.Right)
+		return fmt.Sprintf("(%s %s %s)", left, strings.ToUpper(n.Op), right), append(leftArgs, rightArgs...)
+
+	case Not:
+		inner, args := ToSQL(n.Expr)
+		return fmt.Sprintf("NOT (%s)", inner), args
+
+	case Comparison:
+		return comparisonSQL(n)
+	}
+	return "1 = 1", nil
+}
+
+func comparisonSQL(c Comparison) (string, []interface{}) {
+	col := Fields[c.Field].Column
+	if hasDate(c.Values) {
+		return dateSQL(col, c)
+	}
+
+	switch c.Op {
+	case "=", "!=":
+		op := c.Op
+		if op == "!=" {
+			op = "<>"
+		}
+		return fmt.Sprintf("%s %s ?", col, op), c.Values
+	case "<", "<=", ">", ">=":
+		return fmt.Sprintf("%s %s ?", col, c.Op), c.Values
+	case "in":
+		return fmt.Sprintf("%s IN ?", col), []interface{}{c.Values}
+	case "notin":
+		return fmt.Sprintf("%s NOT IN ?", col), []interface{}{c.Values}
+	case "between":
+		return fmt.Sprintf("%s BETWEEN ? AND ?", col), c.Values
+	case "has":
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(%s) AS ing WHERE LOWER(ing) = LOWER(?))", col), c.Values
+	case "contains":
+		return fmt.Sprintf("LOWER(%s) LIKE ? ESCAPE '\\'", col), []interface{}{"%" + escapeLike(strings.ToLower(c.Values[0].(string))) + "%"}
+	case "isnull":
+		return col + " IS NULL", nil
+	case "notnull":
+		return col + " IS NOT NULL", nil
+	}
+	return "1 = 1", nil
+}
+
+// kondisi dengan literal tanggal jadi rentang hari: <= D berarti < awal hari berikutnya
+func dateSQL(col string, c Comparison) (string, []interface{}) {
+	switch c.Op {
+	case "=":
+		d := c.Values[0].(Date)
+		return fmt.Sprintf("(%s >= ? AND %s < ?)", col, col), []interface{}{d.Start, d.End}
+	case "!=":
+		d := c.Values[0].(Date)
+		return fmt.Sprintf("(%s < ? OR %s >= ?)", col, col), []interface{}{d.Start, d.End}
+	case "<", ">=":
+		return fmt.Sprintf("%s %s ?", col, c.Op), []interface{}{c.Values[0].(Date).Start}
+	case "<=":
+		return fmt.Sprintf("%s < ?", col), []interface{}{c.Values[0].(Date).End}
+	case ">":
+		return fmt.Sprintf("%s >= ?", col), []interface{}{c.Values[0].(Date).End}
+	case "between":
+		low, high := c.Values[0], c.Values[1]
+		if d, ok := low.(Date); ok {
+			low = d.Start
+		}
+		if d, ok := high.(Date); ok {
+			return fmt.Sprintf("(%s >= ? AND %s < ?)", col, col), []interface{}{low, d.End}
+		}
+		return fmt.Sprintf("(%s >= ? AND %s <= ?)", col, col), []interface{}{low, high}
+	case "in", "notin":
+		parts := make([]string, 0, len(c.Values))
+		var args []interface{}
+		for _, v := range c.Values {
+			if d, ok := v.(Date); ok {
+				parts = append(parts, fmt.Sprintf("(%s >= ? AND %s < ?)", col, col))
+				args = append(args, d.Start, d.End)
+				continue
+			}
+			parts = append(parts, col+" = ?")
+			args = append(args, v)
+		}
+		sql := "(" + strings.Join(parts, " OR ") + ")"
+		if c.Op == "notin" {
+			sql = "NOT " + sql
+		}
+		return sql, args
+	}
+	return "1 = 1", nil
+}
+
+func hasDate(values []interface{}) bool {
+	for _, v := range values {
+		if _, ok := v.(Date); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// parse lalu langsung terjemahkan ke SQL
+func Compile(input string) (string, []interface{}, error) {
+	node, err := Parse(input)
+	if err != nil {
+		return "", nil, err
+	}
+	sql, args := ToSQL(node)
+	return sql, args, nil
+}
@@ -0,0 +1,61 @@
+package gemini
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/google/generative-ai-go/genai"
+)
+
+const embeddingModelName = "text-embedding-004"
+
+// Embedder - embedding provider pakai Gemini
+type Embedder struct {
+    client *Client
+    model  *genai.EmbeddingModel
+}
+
+// NewEmbedder - buat embedder Gemini baru
+func NewEmbedder(apiKey string) (*Embedder, error) {
+    client, err := NewClient(apiKey)
+    if err != nil {
+        return nil, err
+    }
+
+    model := client.client.EmbeddingModel(embeddingModelName)
+    model.TaskType = genai.TaskTypeSemanticSimilarity
+
+    return &Embedder{
+        client: client,
+        model:  model,
+    }, nil
+}
+
+// Name - nama provider
+func (e *Embedder) Name() string {
+    return "gemini-" + embeddingModelName
+}
+
+// Embed - embed satu teks
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+    ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+    defer cancel()
+
+    res, err := e.model.EmbedContent(ctx, genai.Text(text))
+    if err != nil {
+        return nil, fmt.Errorf("gemini embedding failed: %v", err)
+    }
+    if res.Embedding == nil || len(res.Embedding.Values) == 0 {
+        return nil, fmt.Errorf("empty embedding from Gemini")
+    }
+    return res.Embedding.Values, nil
+}
+
+// Close - Close koneksi
+func (e *Embedder) Close() error {
+    if e.client != nil {
+        return e.client.Close()
+    }
+    return nil
+}
@@ -2,7 +2,7 @@ package gemini
 
 // Request untuk rekomendasi
 type RecommendationReq struct{
-	Query	string	`json:"query"`
+	Query	string	`json:"query" validate:"required"`
 	MaxPrice	float64	`json:"max_price,omitempty"`
 	Diet	string	`json:"diet,omitempty"`
 	Exclude []string	`json:"exclude,omitempty"`
@@ -18,6 +18,6 @@ type RecommendationResult struct{
 
 // rekomendasi per menu
 type MenuRecommendation	struct{
-	Menu	interface{}	`json:"menu"`
-	MatchReason	string	`json:"match_reason`
+	Menu	interface{}	`json:"menu" openapi:"Menu"`
+	MatchReason	string	`json:"match_reason"`
 } 
\ No newline at end of file
@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/services"
+)
+
+// error resolver dengan extensions (code, status, fields) seperti problem+json di REST
+type Error struct {
+	err        *services.Error
+	message    string
+	existingID uint
+	permission string
+}
+
+// ubah error service jadi error GraphQL dalam bahasa request
+func newError(ctx context.Context, err error) *Error {
+	lang := language(ctx)
+	domain := services.Classify(err).Localize(lang)
+	gqlErr := &Error{err: domain, message: domain.Message}
+
+	var duplicate *services.DuplicateMenuError
+	if errors.As(err, &duplicate) {
+		gqlErr.existingID = duplicate.ExistingID
+	}
+	var permission *services.PermissionError
+	if errors.As(err, &permission) {
+		gqlErr.permission = permission.Permission
+	}
+
+	// detail error internal tidak dikirim ke client di production
+	if domain.Status() >= 500 && config.GetConfig() != nil && config.GetConfig().AppEnv == "production" {
+		gqlErr.message = i18n.T(lang, i18n.MsgInternalHidden)
+	}
+	return gqlErr
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{
+		"code":   e.err.Code,
+		"status": e.err.Status(),
+	}
+	if len(e.err.Fields) > 0 {
+		extensions["fields"] = e.err.Fields
+	}
+	if e.existingID != 0 {
+		extensions["existing_id"] = e.existingID
+	}
+	if e.permission != "" {
+		extensions["missing_permission"] = e.permission
+	}
+	return extensions
+}
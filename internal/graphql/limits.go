@@ -0,0 +1,160 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/services"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// batas query, 0 = tanpa batas
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// perkiraan jumlah item field list untuk complexity: argumen jumlah item dan
+// default-nya. Biaya field anak dikalikan jumlah item ini
+type listSize struct {
+	arg    string
+	size   int
+	factor int
+}
+
+// kedalaman maksimal subtree introspeksi (__schema, __type). Dicek terpisah dari
+// MaxDepth karena query introspeksi standar (ofType bertingkat) lebih dalam dari query biasa
+const MaxIntrospectionDepth = 15
+
+// categories: biaya items dikali jumlah kategori (foods, drinks, desserts, snacks)
+const categoryCount = 4
+
+var listFields = map[string]listSize{
+	"menus":           {arg: "first", size: defaultPageSize, factor: 1},
+	"search":          {arg: "first", size: defaultPageSize, factor: 1},
+	"categories":      {arg: "perCategory", size: defaultPerCategory, factor: categoryCount},
+	"recommendations": {size: defaultPageSize, factor: 1},
+}
+
+// hitung kedalaman & complexity operasi yang akan dijalankan. Setiap field
+// bernilai 1 ditambah biaya field anak (dikali jumlah item untuk field list).
+// Field introspeksi ikut complexity, kedalamannya dibatasi MaxIntrospectionDepth
+// (atau MaxDepth kalau lebih besar); __typename tidak dihitung
+func checkLimits(doc *ast.Document, operationName string, variables map[string]interface{}, limits Limits) error {
+	a := &analyzer{fragments: make(map[string]*ast.FragmentDefinition), variables: variables}
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operation == nil || (d.Name != nil && d.Name.Value == operationName) {
+				operation = d
+			}
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	depth, cost := a.measure(operation.SelectionSet, map[string]bool{})
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return services.Invalid(services.CodeQueryTooDeep, i18n.MsgQueryTooDeep, depth, limits.MaxDepth)
+	}
+	if limits.MaxDepth > 0 {
+		maxIntrospection := max(limits.MaxDepth, MaxIntrospectionDepth)
+		if a.introspectionDepth > maxIntrospection {
+			return services.Invalid(services.CodeQueryTooDeep, i18n.MsgQueryTooDeep, a.introspectionDepth, maxIntrospection)
+		}
+	}
+	if limits.MaxComplexity > 0 && cost > limits.MaxComplexity {
+		return services.Invalid(services.CodeQueryTooComplex, i18n.MsgQueryTooComplex, cost, limits.MaxComplexity)
+	}
+	return nil
+}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	// kedalaman subtree introspeksi terdalam
+	introspectionDepth int
+}
+
+// kedalaman & biaya selection set, fragment yang sedang dibuka dilewati
+// supaya fragment rekursif tidak membuat loop
+func (a *analyzer) measure(set *ast.SelectionSet, open map[string]bool) (depth, cost int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.Name.Value == "__typename" {
+				continue
+			}
+			d, c = a.measure(s.SelectionSet, open)
+			d++
+			c = 1 + c*a.multiplier(s)
+			// __schema / __type: biaya tetap dihitung, kedalaman dicek terpisah
+			if strings.HasPrefix(s.Name.Value, "__") {
+				a.introspectionDepth = max(a.introspectionDepth, d)
+				cost += c
+				continue
+			}
+		case *ast.InlineFragment:
+			d, c = a.measure(s.SelectionSet, open)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || open[name] {
+				continue
+			}
+			open[name] = true
+			d, c = a.measure(fragment.SelectionSet, open)
+			delete(open, name)
+		}
+		if d > depth {
+			depth = d
+		}
+		cost += c
+	}
+	return depth, cost
+}
+
+// jumlah item field list dari argumen (literal atau variabel), dibatasi maxPageSize
+func (a *analyzer) multiplier(field *ast.Field) int {
+	list, ok := listFields[field.Name.Value]
+	if !ok {
+		return 1
+	}
+
+	size := list.size
+	for _, arg := range field.Arguments {
+		if list.arg == "" || arg.Name.Value != list.arg {
+			continue
+		}
+		if n, ok := a.intValue(arg.Value); ok {
+			size = clamp(n, list.size, maxPageSize)
+		}
+	}
+	return size * list.factor
+}
+
+func (a *analyzer) intValue(value ast.Value) (int, bool) {
+	switch v := value.(type) {
+	case *ast.IntValue:
+		n, err := strconv.Atoi(v.Value)
+		return n, err == nil
+	case *ast.Variable:
+		switch n := a.variables[v.Name.Value].(type) {
+		case float64:
+			return int(n), true
+		case int:
+			return n, true
+		}
+	}
+	return 0, false
+}
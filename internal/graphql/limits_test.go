@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"GDGOC-API/internal/models"
+
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestLimits(t *testing.T) {
+	server, err := NewServer(NewResolver(nil, nil, nil), Limits{MaxDepth: 10, MaxComplexity: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// __type bertingkat lewat fields { type { fields ... } }
+	nestedType := func(levels int) string {
+		return "{ __type(name: \"Menu\") { " +
+			strings.Repeat("fields { type { ", levels) + "name" + strings.Repeat(" } }", levels) +
+			" } }"
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "introspeksi standar", query: testutil.IntrospectionQuery},
+		{name: "__typename tidak dihitung", query: `{ __typename }`},
+		{name: "__type dangkal", query: nestedType(2)},
+		{name: "__type bertingkat terlalu dalam", query: nestedType(8), wantErr: "15"},
+		{name: "__schema ikut complexity", query: "{ " + manySchemas(400) + " }", wantErr: "1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := server.Execute(context.Background(), models.GraphQLRequest{Query: tt.query})
+			if tt.wantErr == "" {
+				if result.HasErrors() {
+					t.Fatalf("errors: %v", result.Errors)
+				}
+				return
+			}
+			if !result.HasErrors() {
+				t.Fatal("query harus ditolak")
+			}
+			if msg := result.Errors[0].Message; !strings.Contains(msg, tt.wantErr) {
+				t.Fatalf("error %q tidak menyebut batas %s", msg, tt.wantErr)
+			}
+		})
+	}
+}
+
+// banyak alias __schema dalam satu query, biaya 3 per alias
+func manySchemas(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "s%d: __schema { queryType { name } } ", i)
+	}
+	return sb.String()
+}
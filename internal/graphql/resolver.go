@@ -0,0 +1,425 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/gemini"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/services"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	defaultPageSize    = 10
+	maxPageSize        = 100
+	defaultPerCategory = 10
+)
+
+// rekomendasi menu (Gemini dengan fallback), lang = bahasa pesan di hasil
+type RecommendFunc func(lang string, req gemini.RecommendationReq) (*gemini.RecommendationResult, error)
+
+// resolver query & mutation GraphQL
+type Resolver struct {
+	service   *services.MenuService
+	analytics *services.AnalyticsService
+	recommend RecommendFunc
+}
+
+func NewResolver(service *services.MenuService, analytics *services.AnalyticsService, recommend RecommendFunc) *Resolver {
+	return &Resolver{
+		service:   service,
+		analytics: analytics,
+		recommend: recommend,
+	}
+}
+
+type contextKey string
+
+const languageKey contextKey = "lang"
+
+// simpan bahasa request di context untuk pesan & error resolver
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey, lang)
+}
+
+func language(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey).(string); ok {
+		return lang
+	}
+	return i18n.Default()
+}
+
+// query menu(id)
+func (r *Resolver) menu(p gql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+
+	menu, err := r.service.GetMenuByID(id, models.MenuView{Include: includes(p.Info)})
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+	return menu, nil
+}
+
+// query menus(filter, sort, first, after, before)
+func (r *Resolver) menus(p gql.ResolveParams) (interface{}, error) {
+	filters := models.MenuFilters{Include: includes(p.Info)}
+	if filter, ok := p.Args["filter"].(map[string]interface{}); ok {
+		filters.Query, _ = filter["q"].(string)
+		filters.Category, _ = filter["category"].(string)
+		filters.MinPrice = floatArg(filter, "minPrice")
+		filters.MaxPrice = floatArg(filter, "maxPrice")
+		filters.MaxCalories, _ = filter["maxCalories"].(int)
+		filters.Filter, _ = filter["expr"].(string)
+	}
+	page := pageParams(p)
+	filters.Sort, filters.PerPage = page.Sort, page.PerPage
+	filters.Cursor, filters.CursorMode, filters.WithTotal = page.Cursor, page.CursorMode, page.WithTotal
+
+	menus, pagination, err := r.service.GetAllMenus(filters)
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+	return connection(menus, pagination), nil
+}
+
+// query search(query, sort, first, after, before)
+func (r *Resolver) search(p gql.ResolveParams) (interface{}, error) {
+	query, _ := p.Args["query"].(string)
+	start := time.Now()
+
+	menus, pagination, err := r.service.SearchMenus(query, pageParams(p))
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+
+	resultCount := len(menus)
+	if pagination.Total != nil {
+		resultCount = int(*pagination.Total)
+	}
+	if r.analytics != nil {
+		r.analytics.Record(models.SearchSourceSearch, query, resultCount, time.Since(start))
+	}
+	return connection(menus, pagination), nil
+}
+
+// query categories(perCategory): jumlah per kategori, items hanya diambil kalau dipilih
+func (r *Resolver) categories(p gql.ResolveParams) (interface{}, error) {
+	counts, err := r.service.GroupMenusByCategory("count", 0)
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+
+	var items map[string][]models.Menu
+	if selected(p.Info, "items") {
+		perCategory, _ := p.Args["perCategory"].(int)
+		grouped, err := r.service.GroupMenusByCategory("list", clamp(perCategory, defaultPerCategory, maxPageSize))
+		if err != nil {
+			return nil, newError(p.Context, err)
+		}
+		items, _ = grouped.(map[string][]models.Menu)
+	}
+
+	countMap, _ := counts.(map[string]int64)
+	categories := make([]string, 0, len(countMap))
+	for category := range countMap {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	groups := make([]map[string]interface{}, 0, len(categories))
+	for _, category := range categories {
+		groups = append(groups, map[string]interface{}{
+			"category": category,
+			"count":    countMap[category],
+			"items":    nonNil(items[category]),
+		})
+	}
+	return groups, nil
+}
+
+// query recommendations(input)
+func (r *Resolver) recommendations(p gql.ResolveParams) (interface{}, error) {
+	if err := services.Authorize(p.Context, auth.PermAIUse); err != nil {
+		return nil, newError(p.Context, err)
+	}
+	input, _ := p.Args["input"].(map[string]interface{})
+	req := gemini.RecommendationReq{}
+	req.Query, _ = input["query"].(string)
+	req.MaxPrice = floatArg(input, "maxPrice")
+	req.Diet, _ = input["diet"].(string)
+	req.Exclude = stringList(input["exclude"])
+
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, newError(p.Context, requiredError(p.Context, "query"))
+	}
+
+	result, err := r.recommend(language(p.Context), req)
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+	return result, nil
+}
+
+// mutation createMenu(input)
+func (r *Resolver) createMenu(p gql.ResolveParams) (interface{}, error) {
+	if err := services.Authorize(p.Context, auth.PermMenuWrite); err != nil {
+		return nil, newError(p.Context, err)
+	}
+	input, _ := p.Args["input"].(map[string]interface{})
+	req := models.CreateMenuRequest{
+		Name:        stringArg(input, "name"),
+		Category:    stringArg(input, "category"),
+		Calories:    intPtrArg(input, "calories"),
+		Price:       floatArg(input, "price"),
+		Ingredients: stringList(input["ingredients"]),
+		Description: stringArg(input, "description"),
+	}
+	if externalID, ok := input["externalId"].(string); ok {
+		req.ExternalID = &externalID
+	}
+
+	menu, err := r.service.CreateMenu(req)
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+	return menu, nil
+}
+
+// mutation updateMenu(id, input, version), ubah harga butuh price:write (dicek MenuService)
+func (r *Resolver) updateMenu(p gql.ResolveParams) (interface{}, error) {
+	if err := services.Authorize(p.Context, auth.PermMenuWrite); err != nil {
+		return nil, newError(p.Context, err)
+	}
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+	version, err := expectedVersion(p.Args)
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+
+	input, _ := p.Args["input"].(map[string]interface{})
+	req := models.UpdateMenuRequest{
+		Name:        stringArg(input, "name"),
+		Category:    stringArg(input, "category"),
+		Calories:    intPtrArg(input, "calories"),
+		Price:       floatArg(input, "price"),
+		Ingredients: stringList(input["ingredients"]),
+		Description: stringArg(input, "description"),
+	}
+
+	menu, err := r.service.UpdateMenu(p.Context, id, req, version)
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+	return menu, nil
+}
+
+// mutation deleteMenu(id, version)
+func (r *Resolver) deleteMenu(p gql.ResolveParams) (interface{}, error) {
+	if err := services.Authorize(p.Context, auth.PermMenuDelete); err != nil {
+		return nil, newError(p.Context, err)
+	}
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+	version, err := expectedVersion(p.Args)
+	if err != nil {
+		return nil, newError(p.Context, err)
+	}
+
+	if err := r.service.DeleteMenu(id, version); err != nil {
+		return nil, newError(p.Context, err)
+	}
+	return map[string]interface{}{
+		"id":      formatID(id),
+		"message": i18n.T(language(p.Context), i18n.MsgMenuDeleted),
+	}, nil
+}
+
+// paging cursor dari argumen first/after/before, totalCount hanya dihitung kalau dipilih
+func pageParams(p gql.ResolveParams) models.PageParams {
+	first, _ := p.Args["first"].(int)
+	params := models.PageParams{
+		PerPage:    clamp(first, defaultPageSize, maxPageSize),
+		CursorMode: true,
+		WithTotal:  selected(p.Info, "totalCount"),
+	}
+	params.Sort, _ = p.Args["sort"].(string)
+	if after, ok := p.Args["after"].(string); ok && after != "" {
+		params.Cursor = after
+	} else if before, ok := p.Args["before"].(string); ok {
+		params.Cursor = before
+	}
+	return params
+}
+
+func connection(menus []models.Menu, pagination *models.PaginationMeta) map[string]interface{} {
+	edges := make([]map[string]interface{}, 0, len(menus))
+	for i := range menus {
+		edges = append(edges, map[string]interface{}{"node": &menus[i]})
+	}
+
+	result := map[string]interface{}{
+		"edges": edges,
+		"nodes": nonNil(menus),
+		"pageInfo": map[string]interface{}{
+			"hasNextPage":     pagination != nil && pagination.NextCursor != "",
+			"hasPreviousPage": pagination != nil && pagination.PrevCursor != "",
+			"startCursor":     nil,
+			"endCursor":       nil,
+		},
+	}
+	if pagination == nil {
+		return result
+	}
+	pageInfo := result["pageInfo"].(map[string]interface{})
+	if pagination.PrevCursor != "" {
+		pageInfo["startCursor"] = pagination.PrevCursor
+	}
+	if pagination.NextCursor != "" {
+		pageInfo["endCursor"] = pagination.NextCursor
+	}
+	if pagination.Total != nil {
+		result["totalCount"] = *pagination.Total
+	}
+	return result
+}
+
+// versi menu yang diharapkan, wajib kalau REQUIRE_IF_MATCH aktif
+func expectedVersion(args map[string]interface{}) (*uint, error) {
+	version, ok := args["version"].(int)
+	if !ok {
+		if cfg := config.GetConfig(); cfg != nil && cfg.RequireIfMatch {
+			return nil, services.Errorf(services.KindPreconditionRequired, services.CodeIfMatchRequired, i18n.MsgVersionRequired)
+		}
+		return nil, nil
+	}
+	if version < 0 {
+		version = 0
+	}
+	v := uint(version)
+	return &v, nil
+}
+
+func parseID(value interface{}) (uint, error) {
+	s, _ := value.(string)
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, services.Invalid(services.CodeInvalidID, i18n.MsgInvalidID)
+	}
+	return uint(id), nil
+}
+
+func requiredError(ctx context.Context, field string) error {
+	validation := services.Errorf(services.KindValidation, services.CodeValidation, i18n.MsgValidationFailed)
+	validation.Fields = []models.FieldError{{Field: field, Rule: "required", Message: i18n.T(language(ctx), i18n.MsgFieldRequired, field)}}
+	return validation
+}
+
+// relasi yang perlu di-load sesuai field yang dipilih di bawah field ini
+func includes(info gql.ResolveInfo) string {
+	var relations []string
+	for _, relation := range []string{"variants", "tags", "images"} {
+		if selected(info, relation) {
+			relations = append(relations, relation)
+		}
+	}
+	return strings.Join(relations, ",")
+}
+
+// true kalau field name dipilih di mana pun di bawah field yang sedang di-resolve
+func selected(info gql.ResolveInfo, name string) bool {
+	for _, field := range info.FieldASTs {
+		if containsField(field.SelectionSet, name, info.Fragments, map[string]bool{}) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsField(set *ast.SelectionSet, name string, fragments map[string]ast.Definition, visited map[string]bool) bool {
+	if set == nil {
+		return false
+	}
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.Name.Value == name || containsField(s.SelectionSet, name, fragments, visited) {
+				return true
+			}
+		case *ast.InlineFragment:
+			if containsField(s.SelectionSet, name, fragments, visited) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			fragmentName := s.Name.Value
+			if visited[fragmentName] {
+				continue
+			}
+			visited[fragmentName] = true
+			if fragment, ok := fragments[fragmentName].(*ast.FragmentDefinition); ok && containsField(fragment.SelectionSet, name, fragments, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nilai argumen jumlah: kosong/<1 = default, dibatasi max
+func clamp(value, fallback, max int) int {
+	if value < 1 {
+		return fallback
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+func stringArg(input map[string]interface{}, key string) string {
+	value, _ := input[key].(string)
+	return value
+}
+
+func floatArg(input map[string]interface{}, key string) float64 {
+	switch value := input[key].(type) {
+	case float64:
+		return value
+	case int:
+		return float64(value)
+	}
+	return 0
+}
+
+func intPtrArg(input map[string]interface{}, key string) *int {
+	if value, ok := input[key].(int); ok {
+		return &value
+	}
+	return nil
+}
+
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -0,0 +1,301 @@
+package graphql
+
+import (
+	"strconv"
+
+	"GDGOC-API/internal/gemini"
+	"GDGOC-API/internal/models"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// schema GraphQL katalog menu: query menu/menus/search/categories/recommendations
+// dan mutation CRUD menu, resolver memakai MenuService yang sama dengan REST
+func NewSchema(r *Resolver) (gql.Schema, error) {
+	variant := gql.NewObject(gql.ObjectConfig{
+		Name: "MenuVariant",
+		Fields: gql.Fields{
+			"id":    &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: variantField(func(v models.MenuVariant) interface{} { return formatID(v.ID) })},
+			"name":  &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: variantField(func(v models.MenuVariant) interface{} { return v.Name })},
+			"price": &gql.Field{Type: gql.NewNonNull(gql.Float), Resolve: variantField(func(v models.MenuVariant) interface{} { return v.Price })},
+		},
+	})
+
+	tag := gql.NewObject(gql.ObjectConfig{
+		Name: "Tag",
+		Fields: gql.Fields{
+			"id":   &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: tagField(func(t models.Tag) interface{} { return formatID(t.ID) })},
+			"name": &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: tagField(func(t models.Tag) interface{} { return t.Name })},
+		},
+	})
+
+	image := gql.NewObject(gql.ObjectConfig{
+		Name: "MenuImage",
+		Fields: gql.Fields{
+			"id":       &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: imageField(func(i models.MenuImage) interface{} { return formatID(i.ID) })},
+			"url":      &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: imageField(func(i models.MenuImage) interface{} { return i.URL })},
+			"alt":      &gql.Field{Type: gql.String, Resolve: imageField(func(i models.MenuImage) interface{} { return i.Alt })},
+			"position": &gql.Field{Type: gql.NewNonNull(gql.Int), Resolve: imageField(func(i models.MenuImage) interface{} { return i.Position })},
+		},
+	})
+
+	menu := gql.NewObject(gql.ObjectConfig{
+		Name: "Menu",
+		Fields: gql.Fields{
+			"id":          &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: menuField(func(m *models.Menu) interface{} { return formatID(m.ID) })},
+			"externalId":  &gql.Field{Type: gql.String, Resolve: menuField(func(m *models.Menu) interface{} { return m.ExternalID })},
+			"name":        &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: menuField(func(m *models.Menu) interface{} { return m.Name })},
+			"category":    &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: menuField(func(m *models.Menu) interface{} { return m.Category })},
+			"calories":    &gql.Field{Type: gql.Int, Resolve: menuField(func(m *models.Menu) interface{} { return m.Calories })},
+			"price":       &gql.Field{Type: gql.NewNonNull(gql.Float), Resolve: menuField(func(m *models.Menu) interface{} { return m.Price })},
+			"ingredients": &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String))), Resolve: menuField(func(m *models.Menu) interface{} { return nonNil([]string(m.Ingredients)) })},
+			"description": &gql.Field{Type: gql.String, Resolve: menuField(func(m *models.Menu) interface{} { return m.Description })},
+			"viewCount":   &gql.Field{Type: gql.NewNonNull(gql.Int), Resolve: menuField(func(m *models.Menu) interface{} { return m.ViewCount })},
+			"version":     &gql.Field{Type: gql.NewNonNull(gql.Int), Resolve: menuField(func(m *models.Menu) interface{} { return m.Version })},
+			"createdAt":   &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: menuField(func(m *models.Menu) interface{} { return m.CreatedAt })},
+			"updatedAt":   &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: menuField(func(m *models.Menu) interface{} { return m.UpdatedAt })},
+			// relasi hanya di-load kalau dipilih di query
+			"variants": &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(variant))), Resolve: menuField(func(m *models.Menu) interface{} { return nonNil(m.Variants) })},
+			"tags":     &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(tag))), Resolve: menuField(func(m *models.Menu) interface{} { return nonNil(m.Tags) })},
+			"images":   &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(image))), Resolve: menuField(func(m *models.Menu) interface{} { return nonNil(m.Images) })},
+		},
+	})
+
+	// cursor connection: endCursor untuk halaman berikutnya (after),
+	// startCursor untuk halaman sebelumnya (before)
+	pageInfo := gql.NewObject(gql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: gql.Fields{
+			"hasNextPage":     &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"hasPreviousPage": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"startCursor":     &gql.Field{Type: gql.String},
+			"endCursor":       &gql.Field{Type: gql.String},
+		},
+	})
+
+	edge := gql.NewObject(gql.ObjectConfig{
+		Name: "MenuEdge",
+		Fields: gql.Fields{
+			"node": &gql.Field{Type: gql.NewNonNull(menu)},
+		},
+	})
+
+	connection := gql.NewObject(gql.ObjectConfig{
+		Name: "MenuConnection",
+		Fields: gql.Fields{
+			"edges":      &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(edge)))},
+			"nodes":      &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(menu)))},
+			"pageInfo":   &gql.Field{Type: gql.NewNonNull(pageInfo)},
+			"totalCount": &gql.Field{Type: gql.Int, Description: "Total data, hanya dihitung kalau field ini dipilih"},
+		},
+	})
+
+	categoryGroup := gql.NewObject(gql.ObjectConfig{
+		Name: "CategoryGroup",
+		Fields: gql.Fields{
+			"category": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"count":    &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"items":    &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(menu))), Description: "Maksimal perCategory menu"},
+		},
+	})
+
+	recommendation := gql.NewObject(gql.ObjectConfig{
+		Name: "Recommendation",
+		Fields: gql.Fields{
+			"menu":        &gql.Field{Type: gql.NewNonNull(menu), Resolve: recommendationField(func(m gemini.MenuRecommendation) interface{} { return m.Menu })},
+			"matchReason": &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: recommendationField(func(m gemini.MenuRecommendation) interface{} { return m.MatchReason })},
+		},
+	})
+
+	recommendationResult := gql.NewObject(gql.ObjectConfig{
+		Name: "RecommendationResult",
+		Fields: gql.Fields{
+			"query":           &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: resultField(func(r *gemini.RecommendationResult) interface{} { return r.Query })},
+			"recommendations": &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(recommendation))), Resolve: resultField(func(r *gemini.RecommendationResult) interface{} { return nonNil(r.Recommendations) })},
+			"searchSummary":   &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: resultField(func(r *gemini.RecommendationResult) interface{} { return r.SearchSummary })},
+			"suggestions":     &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String))), Resolve: resultField(func(r *gemini.RecommendationResult) interface{} { return nonNil(r.Suggestions) })},
+		},
+	})
+
+	deletePayload := gql.NewObject(gql.ObjectConfig{
+		Name: "DeleteMenuPayload",
+		Fields: gql.Fields{
+			"id":      &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"message": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+
+	menuFilter := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "MenuFilter",
+		Fields: gql.InputObjectConfigFieldMap{
+			"q":           &gql.InputObjectFieldConfig{Type: gql.String, Description: "Cari di nama & deskripsi"},
+			"category":    &gql.InputObjectFieldConfig{Type: gql.String},
+			"minPrice":    &gql.InputObjectFieldConfig{Type: gql.Float},
+			"maxPrice":    &gql.InputObjectFieldConfig{Type: gql.Float},
+			"maxCalories": &gql.InputObjectFieldConfig{Type: gql.Int},
+			"expr":        &gql.InputObjectFieldConfig{Type: gql.String, Description: "Ekspresi filter, sama dengan parameter filter di REST"},
+		},
+	})
+
+	menuInputFields := func(withExternalID bool) gql.InputObjectConfigFieldMap {
+		fields := gql.InputObjectConfigFieldMap{
+			"name":        &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"category":    &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"calories":    &gql.InputObjectFieldConfig{Type: gql.Int},
+			"price":       &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.Float)},
+			"ingredients": &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String)))},
+			"description": &gql.InputObjectFieldConfig{Type: gql.String},
+		}
+		if withExternalID {
+			fields["externalId"] = &gql.InputObjectFieldConfig{Type: gql.String}
+		}
+		return fields
+	}
+	createInput := gql.NewInputObject(gql.InputObjectConfig{Name: "CreateMenuInput", Fields: menuInputFields(true)})
+	updateInput := gql.NewInputObject(gql.InputObjectConfig{Name: "UpdateMenuInput", Fields: menuInputFields(false)})
+
+	recommendationInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "RecommendationInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"query":    &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"maxPrice": &gql.InputObjectFieldConfig{Type: gql.Float},
+			"diet":     &gql.InputObjectFieldConfig{Type: gql.String, Description: "vegetarian, vegan atau low-carb"},
+			"exclude":  &gql.InputObjectFieldConfig{Type: gql.NewList(gql.NewNonNull(gql.String))},
+		},
+	})
+
+	pageArgs := func(args gql.FieldConfigArgument) gql.FieldConfigArgument {
+		args["sort"] = &gql.ArgumentConfig{Type: gql.String, Description: "Urutan, contoh: price:asc,name:desc"}
+		args["first"] = &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultPageSize, Description: "Jumlah per halaman (max 100)"}
+		args["after"] = &gql.ArgumentConfig{Type: gql.String, Description: "pageInfo.endCursor dari halaman sebelumnya"}
+		args["before"] = &gql.ArgumentConfig{Type: gql.String, Description: "pageInfo.startCursor untuk mundur satu halaman"}
+		return args
+	}
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"menu": &gql.Field{
+				Type:    menu,
+				Args:    gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}},
+				Resolve: r.menu,
+			},
+			"menus": &gql.Field{
+				Type:    gql.NewNonNull(connection),
+				Args:    pageArgs(gql.FieldConfigArgument{"filter": &gql.ArgumentConfig{Type: menuFilter}}),
+				Resolve: r.menus,
+			},
+			"search": &gql.Field{
+				Type:    gql.NewNonNull(connection),
+				Args:    pageArgs(gql.FieldConfigArgument{"query": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)}}),
+				Resolve: r.search,
+			},
+			"categories": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(categoryGroup))),
+				Args: gql.FieldConfigArgument{
+					"perCategory": &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultPerCategory, Description: "Jumlah items per kategori (max 100)"},
+				},
+				Resolve: r.categories,
+			},
+			"recommendations": &gql.Field{
+				Type:    gql.NewNonNull(recommendationResult),
+				Args:    gql.FieldConfigArgument{"input": &gql.ArgumentConfig{Type: gql.NewNonNull(recommendationInput)}},
+				Resolve: r.recommendations,
+			},
+		},
+	})
+
+	// version = versi menu yang terakhir dibaca (seperti If-Match di REST)
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: gql.Fields{
+			"createMenu": &gql.Field{
+				Type:    gql.NewNonNull(menu),
+				Args:    gql.FieldConfigArgument{"input": &gql.ArgumentConfig{Type: gql.NewNonNull(createInput)}},
+				Resolve: r.createMenu,
+			},
+			"updateMenu": &gql.Field{
+				Type: gql.NewNonNull(menu),
+				Args: gql.FieldConfigArgument{
+					"id":      &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+					"input":   &gql.ArgumentConfig{Type: gql.NewNonNull(updateInput)},
+					"version": &gql.ArgumentConfig{Type: gql.Int},
+				},
+				Resolve: r.updateMenu,
+			},
+			"deleteMenu": &gql.Field{
+				Type: gql.NewNonNull(deletePayload),
+				Args: gql.FieldConfigArgument{
+					"id":      &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+					"version": &gql.ArgumentConfig{Type: gql.Int},
+				},
+				Resolve: r.deleteMenu,
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// resolver field dari source models.Menu / *models.Menu
+func menuField(get func(m *models.Menu) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		switch m := p.Source.(type) {
+		case *models.Menu:
+			return get(m), nil
+		case models.Menu:
+			return get(&m), nil
+		}
+		return nil, nil
+	}
+}
+
+func variantField(get func(v models.MenuVariant) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		v, _ := p.Source.(models.MenuVariant)
+		return get(v), nil
+	}
+}
+
+func tagField(get func(t models.Tag) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		t, _ := p.Source.(models.Tag)
+		return get(t), nil
+	}
+}
+
+func imageField(get func(i models.MenuImage) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		i, _ := p.Source.(models.MenuImage)
+		return get(i), nil
+	}
+}
+
+func recommendationField(get func(m gemini.MenuRecommendation) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		m, _ := p.Source.(gemini.MenuRecommendation)
+		return get(m), nil
+	}
+}
+
+func resultField(get func(r *gemini.RecommendationResult) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		r, _ := p.Source.(*gemini.RecommendationResult)
+		if r == nil {
+			return nil, nil
+		}
+		return get(r), nil
+	}
+}
+
+// slice nil jadi slice kosong, field list di schema non-null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func formatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
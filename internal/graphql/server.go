@@ -0,0 +1,53 @@
+package graphql
+
+import (
+	"context"
+
+	"GDGOC-API/internal/models"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// eksekusi query GraphQL: parse, validasi schema, cek batas depth/complexity, lalu jalankan
+type Server struct {
+	schema gql.Schema
+	limits Limits
+}
+
+func NewServer(resolver *Resolver, limits Limits) (*Server, error) {
+	schema, err := NewSchema(resolver)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{schema: schema, limits: limits}, nil
+}
+
+// jalankan request, error parse/validasi/batas dikembalikan di Result.Errors tanpa data
+func (s *Server) Execute(ctx context.Context, req models.GraphQLRequest) *gql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &gql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if validation := gql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return &gql.Result{Errors: validation.Errors}
+	}
+
+	if err := checkLimits(doc, req.OperationName, req.Variables, s.limits); err != nil {
+		limitErr := newError(ctx, err)
+		return &gql.Result{Errors: gqlerrors.FormatErrors(gqlerrors.NewError(limitErr.Error(), nil, "", nil, nil, limitErr))}
+	}
+
+	return gql.Execute(gql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/grpcapi/menupb"
+	"GDGOC-API/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// service bawaan yang tetap terbuka (health check & reflection)
+var publicServices = []string{"/grpc.health.v1.", "/grpc.reflection."}
+
+// permission tiap RPC MenuService, sama dengan route REST padanannya.
+// RPC yang tidak terdaftar selalu ditolak
+var methodPermissions = map[string]string{
+	menupb.MenuService_GetMenu_FullMethodName:      auth.PermMenuRead,
+	menupb.MenuService_ListMenus_FullMethodName:    auth.PermMenuRead,
+	menupb.MenuService_SearchMenus_FullMethodName:  auth.PermMenuRead,
+	menupb.MenuService_WatchCatalog_FullMethodName: auth.PermMenuRead,
+	menupb.MenuService_CreateMenu_FullMethodName:   auth.PermMenuWrite,
+	menupb.MenuService_UpdateMenu_FullMethodName:   auth.PermMenuWrite,
+	menupb.MenuService_DeleteMenu_FullMethodName:   auth.PermMenuDelete,
+}
+
+// autentikasi RPC unary dari metadata authorization (Bearer) atau x-api-key
+// lalu cek permission RPC, principal disimpan di context
+func (s *Server) unaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuth(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: stream, ctx: ctx})
+}
+
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if s.auth == nil {
+		return ctx, nil
+	}
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	credential := credentials(ctx)
+	if credential == "" {
+		return nil, services.ErrUnauthorized
+	}
+	var principal *auth.Principal
+	var err error
+	if _, ok := auth.APIKeyPrefix(credential); ok {
+		principal, err = s.auth.AuthenticateAPIKey(credential)
+	} else {
+		principal, err = s.auth.AuthenticateToken(credential)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	perm, ok := methodPermissions[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "RPC %s tidak punya permission terdaftar", method)
+	}
+	if !principal.Can(perm) {
+		return nil, &services.PermissionError{Permission: perm}
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+func credentials(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return values[0]
+	}
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// stream dengan context yang sudah berisi principal
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,116 @@
+package grpcapi
+
+import (
+	"strings"
+
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/grpcapi/menupb"
+	"GDGOC-API/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var eventTypes = map[events.Type]menupb.CatalogEvent_Type{
+	events.MenuCreated: menupb.CatalogEvent_TYPE_CREATED,
+	events.MenuUpdated: menupb.CatalogEvent_TYPE_UPDATED,
+	events.MenuDeleted: menupb.CatalogEvent_TYPE_DELETED,
+}
+
+func toMenu(menu *models.Menu) *menupb.Menu {
+	pb := &menupb.Menu{
+		Id:          uint32(menu.ID),
+		ExternalId:  menu.ExternalID,
+		Name:        menu.Name,
+		Category:    menu.Category,
+		Price:       menu.Price,
+		Ingredients: menu.Ingredients,
+		Description: menu.Description,
+		ViewCount:   menu.ViewCount,
+		Version:     uint32(menu.Version),
+		CreatedAt:   timestamppb.New(menu.CreatedAt),
+		UpdatedAt:   timestamppb.New(menu.UpdatedAt),
+	}
+	if menu.Calories != nil {
+		calories := int32(*menu.Calories)
+		pb.Calories = &calories
+	}
+	for _, variant := range menu.Variants {
+		pb.Variants = append(pb.Variants, &menupb.MenuVariant{Id: uint32(variant.ID), Name: variant.Name, Price: variant.Price})
+	}
+	for _, tag := range menu.Tags {
+		pb.Tags = append(pb.Tags, &menupb.Tag{Id: uint32(tag.ID), Name: tag.Name})
+	}
+	for _, image := range menu.Images {
+		pb.Images = append(pb.Images, &menupb.MenuImage{Id: uint32(image.ID), Url: image.URL, Alt: image.Alt, Position: int32(image.Position)})
+	}
+	return pb
+}
+
+func toListResponse(menus []models.Menu, pagination *models.PaginationMeta) *menupb.ListMenusResponse {
+	resp := &menupb.ListMenusResponse{Menus: make([]*menupb.Menu, 0, len(menus))}
+	for i := range menus {
+		resp.Menus = append(resp.Menus, toMenu(&menus[i]))
+	}
+	if pagination != nil {
+		resp.NextPageToken = pagination.NextCursor
+		resp.PrevPageToken = pagination.PrevCursor
+		resp.TotalSize = pagination.Total
+	}
+	return resp
+}
+
+func toEvent(event events.Event) *menupb.CatalogEvent {
+	pb := &menupb.CatalogEvent{
+		Id:         event.ID,
+		Type:       eventTypes[event.Type],
+		MenuId:     uint32(event.MenuID),
+		Version:    uint32(event.Version),
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+	if event.Menu != nil {
+		pb.Menu = toMenu(event.Menu)
+	}
+	return pb
+}
+
+// paging cursor seperti GraphQL, page_token kosong = halaman pertama
+func pageParams(sort string, pageSize int32, pageToken string, withTotal bool) models.PageParams {
+	return models.PageParams{
+		PerPage:    clamp(int(pageSize), defaultPageSize, maxPageSize),
+		Sort:       sort,
+		Cursor:     pageToken,
+		CursorMode: true,
+		WithTotal:  withTotal,
+	}
+}
+
+func include(relations []string) string {
+	return strings.Join(relations, ",")
+}
+
+func intPtr(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	n := int(*value)
+	return &n
+}
+
+func uintPtr(value *uint32) *uint {
+	if value == nil {
+		return nil
+	}
+	n := uint(*value)
+	return &n
+}
+
+// nilai page_size: kosong/<1 = default, dibatasi max
+func clamp(value, fallback, max int) int {
+	if value < 1 {
+		return fallback
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,133 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+	"strconv"
+
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/services"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// domain ErrorInfo di detail status
+const errorDomain = "menu-catalog-api"
+
+// padanan status gRPC untuk kind error service (status HTTP-nya ada di services.kindStatus)
+var kindCode = map[services.ErrorKind]codes.Code{
+	services.KindInternal:             codes.Internal,
+	services.KindInvalid:              codes.InvalidArgument,
+	services.KindUnauthorized:         codes.Unauthenticated,
+	services.KindForbidden:            codes.PermissionDenied,
+	services.KindValidation:           codes.InvalidArgument,
+	services.KindUnprocessable:        codes.FailedPrecondition,
+	services.KindNotFound:             codes.NotFound,
+	services.KindConflict:             codes.Aborted,
+	services.KindPreconditionFailed:   codes.FailedPrecondition,
+	services.KindPreconditionRequired: codes.FailedPrecondition,
+	services.KindUnsupportedMediaType: codes.InvalidArgument,
+	services.KindUnavailable:          codes.Unavailable,
+}
+
+// bahasa request dari metadata accept-language
+func language(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("accept-language"); len(values) > 0 {
+			return i18n.Negotiate(values[0], i18n.Default())
+		}
+	}
+	return i18n.Default()
+}
+
+// ubah error service jadi status gRPC dalam bahasa request, kode error REST
+// dikirim sebagai reason ErrorInfo dan error per field sebagai BadRequest
+func toStatus(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	lang := language(ctx)
+	domain := services.Classify(err).Localize(lang)
+	code, ok := kindCode[domain.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+
+	message := domain.Message
+	if code == codes.Internal && config.GetConfig() != nil && config.GetConfig().AppEnv == "production" {
+		message = i18n.T(lang, i18n.MsgInternalHidden)
+	}
+
+	info := &errdetails.ErrorInfo{Reason: domain.Code, Domain: errorDomain}
+	var duplicate *services.DuplicateMenuError
+	if errors.As(err, &duplicate) {
+		code = codes.AlreadyExists
+		if duplicate.ExistingID != 0 {
+			info.Metadata = map[string]string{"existing_id": strconv.FormatUint(uint64(duplicate.ExistingID), 10)}
+		}
+	}
+	var permission *services.PermissionError
+	if errors.As(err, &permission) {
+		info.Metadata = map[string]string{"missing_permission": permission.Permission}
+	}
+
+	details := []protoadapt.MessageV1{info}
+	if len(domain.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(domain.Fields))
+		for _, field := range domain.Fields {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+				Reason:      field.Rule,
+			})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	st := status.New(code, message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// error handler & recover untuk RPC unary
+func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic di %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, i18n.T(language(ctx), i18n.MsgInternalHidden))
+		}
+	}()
+
+	resp, err = handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return resp, nil
+}
+
+// error handler & recover untuk RPC stream
+func streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	ctx := stream.Context()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic di %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, i18n.T(language(ctx), i18n.MsgInternalHidden))
+		}
+	}()
+
+	if err := handler(srv, stream); err != nil {
+		return toStatus(ctx, err)
+	}
+	return nil
+}
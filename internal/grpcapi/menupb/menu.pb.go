@@ -0,0 +1,1440 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: menu/v1/menu.proto
+
+package menupb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CatalogEvent_Type int32
+
+const (
+	CatalogEvent_TYPE_UNSPECIFIED CatalogEvent_Type = 0
+	CatalogEvent_TYPE_CREATED     CatalogEvent_Type = 1
+	CatalogEvent_TYPE_UPDATED     CatalogEvent_Type = 2
+	CatalogEvent_TYPE_DELETED     CatalogEvent_Type = 3
+)
+
+// Enum value maps for CatalogEvent_Type.
+var (
+	CatalogEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_UPDATED",
+		3: "TYPE_DELETED",
+	}
+	CatalogEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_UPDATED":     2,
+		"TYPE_DELETED":     3,
+	}
+)
+
+func (x CatalogEvent_Type) Enum() *CatalogEvent_Type {
+	p := new(CatalogEvent_Type)
+	*p = x
+	return p
+}
+
+func (x CatalogEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CatalogEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_menu_v1_menu_proto_enumTypes[0].Descriptor()
+}
+
+func (CatalogEvent_Type) Type() protoreflect.EnumType {
+	return &file_menu_v1_menu_proto_enumTypes[0]
+}
+
+func (x CatalogEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CatalogEvent_Type.Descriptor instead.
+func (CatalogEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{14, 0}
+}
+
+type Menu struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId  *string                `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Category    string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Calories    *int32                 `protobuf:"varint,5,opt,name=calories,proto3,oneof" json:"calories,omitempty"`
+	Price       float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	Ingredients []string               `protobuf:"bytes,7,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	Description string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	ViewCount   int64                  `protobuf:"varint,9,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	Version     uint32                 `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// hanya terisi kalau diminta lewat include
+	Variants      []*MenuVariant `protobuf:"bytes,13,rep,name=variants,proto3" json:"variants,omitempty"`
+	Tags          []*Tag         `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	Images        []*MenuImage   `protobuf:"bytes,15,rep,name=images,proto3" json:"images,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Menu) Reset() {
+	*x = Menu{}
+	mi := &file_menu_v1_menu_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Menu) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Menu) ProtoMessage() {}
+
+func (x *Menu) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Menu.ProtoReflect.Descriptor instead.
+func (*Menu) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Menu) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Menu) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *Menu) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Menu) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Menu) GetCalories() int32 {
+	if x != nil && x.Calories != nil {
+		return *x.Calories
+	}
+	return 0
+}
+
+func (x *Menu) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Menu) GetIngredients() []string {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *Menu) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Menu) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *Menu) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Menu) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Menu) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Menu) GetVariants() []*MenuVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Menu) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Menu) GetImages() []*MenuImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+type MenuVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuVariant) Reset() {
+	*x = MenuVariant{}
+	mi := &file_menu_v1_menu_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuVariant) ProtoMessage() {}
+
+func (x *MenuVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuVariant.ProtoReflect.Descriptor instead.
+func (*MenuVariant) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MenuVariant) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuVariant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MenuVariant) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_menu_v1_menu_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Tag) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MenuImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Alt           string                 `protobuf:"bytes,3,opt,name=alt,proto3" json:"alt,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuImage) Reset() {
+	*x = MenuImage{}
+	mi := &file_menu_v1_menu_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuImage) ProtoMessage() {}
+
+func (x *MenuImage) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuImage.ProtoReflect.Descriptor instead.
+func (*MenuImage) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MenuImage) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MenuImage) GetAlt() string {
+	if x != nil {
+		return x.Alt
+	}
+	return ""
+}
+
+func (x *MenuImage) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type GetMenuRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// relasi yang di-load: variants, tags, images
+	Include       []string `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuRequest) Reset() {
+	*x = GetMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuRequest) ProtoMessage() {}
+
+func (x *GetMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMenuRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetMenuRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+// sama dengan query param GET /api/v1/menu, field kosong/0 = tidak difilter
+type MenuFilter struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Q           string                 `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	Category    string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	MinPrice    float64                `protobuf:"fixed64,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice    float64                `protobuf:"fixed64,4,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	MaxCalories int32                  `protobuf:"varint,5,opt,name=max_calories,json=maxCalories,proto3" json:"max_calories,omitempty"`
+	// ekspresi filter, contoh: price < 20000 and category = "drinks"
+	Expr          string `protobuf:"bytes,6,opt,name=expr,proto3" json:"expr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuFilter) Reset() {
+	*x = MenuFilter{}
+	mi := &file_menu_v1_menu_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuFilter) ProtoMessage() {}
+
+func (x *MenuFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuFilter.ProtoReflect.Descriptor instead.
+func (*MenuFilter) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MenuFilter) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *MenuFilter) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *MenuFilter) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *MenuFilter) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *MenuFilter) GetMaxCalories() int32 {
+	if x != nil {
+		return x.MaxCalories
+	}
+	return 0
+}
+
+func (x *MenuFilter) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+type ListMenusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *MenuFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// contoh: -price,name
+	Sort string `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	// default 10, maksimal 100
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token atau prev_page_token dari response sebelumnya
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// hitung total_size (query COUNT tambahan)
+	WithTotal     bool     `protobuf:"varint,5,opt,name=with_total,json=withTotal,proto3" json:"with_total,omitempty"`
+	Include       []string `protobuf:"bytes,6,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenusRequest) Reset() {
+	*x = ListMenusRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenusRequest) ProtoMessage() {}
+
+func (x *ListMenusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenusRequest.ProtoReflect.Descriptor instead.
+func (*ListMenusRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListMenusRequest) GetFilter() *MenuFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListMenusRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListMenusRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMenusRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMenusRequest) GetWithTotal() bool {
+	if x != nil {
+		return x.WithTotal
+	}
+	return false
+}
+
+func (x *ListMenusRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type ListMenusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Menus         []*Menu                `protobuf:"bytes,1,rep,name=menus,proto3" json:"menus,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	PrevPageToken string                 `protobuf:"bytes,3,opt,name=prev_page_token,json=prevPageToken,proto3" json:"prev_page_token,omitempty"`
+	// hanya terisi kalau with_total aktif
+	TotalSize     *int64 `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3,oneof" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenusResponse) Reset() {
+	*x = ListMenusResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenusResponse) ProtoMessage() {}
+
+func (x *ListMenusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenusResponse.ProtoReflect.Descriptor instead.
+func (*ListMenusResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListMenusResponse) GetMenus() []*Menu {
+	if x != nil {
+		return x.Menus
+	}
+	return nil
+}
+
+func (x *ListMenusResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListMenusResponse) GetPrevPageToken() string {
+	if x != nil {
+		return x.PrevPageToken
+	}
+	return ""
+}
+
+func (x *ListMenusResponse) GetTotalSize() int64 {
+	if x != nil && x.TotalSize != nil {
+		return *x.TotalSize
+	}
+	return 0
+}
+
+type SearchMenusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Sort          string                 `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	WithTotal     bool                   `protobuf:"varint,5,opt,name=with_total,json=withTotal,proto3" json:"with_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMenusRequest) Reset() {
+	*x = SearchMenusRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMenusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMenusRequest) ProtoMessage() {}
+
+func (x *SearchMenusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMenusRequest.ProtoReflect.Descriptor instead.
+func (*SearchMenusRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchMenusRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMenusRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchMenusRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchMenusRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchMenusRequest) GetWithTotal() bool {
+	if x != nil {
+		return x.WithTotal
+	}
+	return false
+}
+
+type CreateMenuRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId    *string                `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Calories      *int32                 `protobuf:"varint,4,opt,name=calories,proto3,oneof" json:"calories,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Ingredients   []string               `protobuf:"bytes,6,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMenuRequest) Reset() {
+	*x = CreateMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuRequest) ProtoMessage() {}
+
+func (x *CreateMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuRequest.ProtoReflect.Descriptor instead.
+func (*CreateMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateMenuRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetCalories() int32 {
+	if x != nil && x.Calories != nil {
+		return *x.Calories
+	}
+	return 0
+}
+
+func (x *CreateMenuRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateMenuRequest) GetIngredients() []string {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *CreateMenuRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type UpdateMenuRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category    string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Calories    *int32                 `protobuf:"varint,4,opt,name=calories,proto3,oneof" json:"calories,omitempty"`
+	Price       float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Ingredients []string               `protobuf:"bytes,6,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	Description string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	// versi yang diharapkan (seperti If-Match), wajib kalau REQUIRE_IF_MATCH aktif
+	ExpectedVersion *uint32 `protobuf:"varint,8,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateMenuRequest) Reset() {
+	*x = UpdateMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuRequest) ProtoMessage() {}
+
+func (x *UpdateMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateMenuRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMenuRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetCalories() int32 {
+	if x != nil && x.Calories != nil {
+		return *x.Calories
+	}
+	return 0
+}
+
+func (x *UpdateMenuRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateMenuRequest) GetIngredients() []string {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *UpdateMenuRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteMenuRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteMenuRequest) Reset() {
+	*x = DeleteMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuRequest) ProtoMessage() {}
+
+func (x *DeleteMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteMenuRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteMenuRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteMenuResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuResponse) Reset() {
+	*x = DeleteMenuResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuResponse) ProtoMessage() {}
+
+func (x *DeleteMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMenuResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteMenuResponse) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteMenuResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type WatchCatalogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hanya event menu di kategori ini, kosong = semua kategori
+	Categories    []string `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCatalogRequest) Reset() {
+	*x = WatchCatalogRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCatalogRequest) ProtoMessage() {}
+
+func (x *WatchCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCatalogRequest.ProtoReflect.Descriptor instead.
+func (*WatchCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchCatalogRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type CatalogEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// urutan event, naik per proses server
+	Id      uint64            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type    CatalogEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=menu.v1.CatalogEvent_Type" json:"type,omitempty"`
+	MenuId  uint32            `protobuf:"varint,3,opt,name=menu_id,json=menuId,proto3" json:"menu_id,omitempty"`
+	Version uint32            `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// isi menu setelah perubahan (sebelum dihapus untuk TYPE_DELETED), tanpa relasi
+	Menu          *Menu                  `protobuf:"bytes,5,opt,name=menu,proto3" json:"menu,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogEvent) Reset() {
+	*x = CatalogEvent{}
+	mi := &file_menu_v1_menu_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogEvent) ProtoMessage() {}
+
+func (x *CatalogEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogEvent.ProtoReflect.Descriptor instead.
+func (*CatalogEvent) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CatalogEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CatalogEvent) GetType() CatalogEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return CatalogEvent_TYPE_UNSPECIFIED
+}
+
+func (x *CatalogEvent) GetMenuId() uint32 {
+	if x != nil {
+		return x.MenuId
+	}
+	return 0
+}
+
+func (x *CatalogEvent) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CatalogEvent) GetMenu() *Menu {
+	if x != nil {
+		return x.Menu
+	}
+	return nil
+}
+
+func (x *CatalogEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_menu_v1_menu_proto protoreflect.FileDescriptor
+
+const file_menu_v1_menu_proto_rawDesc = "" +
+	"\n" +
+	"\x12menu/v1/menu.proto\x12\amenu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x04\n" +
+	"\x04Menu\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12$\n" +
+	"\vexternal_id\x18\x02 \x01(\tH\x00R\n" +
+	"externalId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x1f\n" +
+	"\bcalories\x18\x05 \x01(\x05H\x01R\bcalories\x88\x01\x01\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x12 \n" +
+	"\vingredients\x18\a \x03(\tR\vingredients\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\t \x01(\x03R\tviewCount\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\rR\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x120\n" +
+	"\bvariants\x18\r \x03(\v2\x14.menu.v1.MenuVariantR\bvariants\x12 \n" +
+	"\x04tags\x18\x0e \x03(\v2\f.menu.v1.TagR\x04tags\x12*\n" +
+	"\x06images\x18\x0f \x03(\v2\x12.menu.v1.MenuImageR\x06imagesB\x0e\n" +
+	"\f_external_idB\v\n" +
+	"\t_calories\"G\n" +
+	"\vMenuVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\")\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"[\n" +
+	"\tMenuImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x10\n" +
+	"\x03alt\x18\x03 \x01(\tR\x03alt\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\":\n" +
+	"\x0eGetMenuRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\ainclude\x18\x02 \x03(\tR\ainclude\"\xa7\x01\n" +
+	"\n" +
+	"MenuFilter\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x1b\n" +
+	"\tmin_price\x18\x03 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x04 \x01(\x01R\bmaxPrice\x12!\n" +
+	"\fmax_calories\x18\x05 \x01(\x05R\vmaxCalories\x12\x12\n" +
+	"\x04expr\x18\x06 \x01(\tR\x04expr\"\xc8\x01\n" +
+	"\x10ListMenusRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.menu.v1.MenuFilterR\x06filter\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"with_total\x18\x05 \x01(\bR\twithTotal\x12\x18\n" +
+	"\ainclude\x18\x06 \x03(\tR\ainclude\"\xbb\x01\n" +
+	"\x11ListMenusResponse\x12#\n" +
+	"\x05menus\x18\x01 \x03(\v2\r.menu.v1.MenuR\x05menus\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12&\n" +
+	"\x0fprev_page_token\x18\x03 \x01(\tR\rprevPageToken\x12\"\n" +
+	"\n" +
+	"total_size\x18\x04 \x01(\x03H\x00R\ttotalSize\x88\x01\x01B\r\n" +
+	"\v_total_size\"\x99\x01\n" +
+	"\x12SearchMenusRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"with_total\x18\x05 \x01(\bR\twithTotal\"\x81\x02\n" +
+	"\x11CreateMenuRequest\x12$\n" +
+	"\vexternal_id\x18\x01 \x01(\tH\x00R\n" +
+	"externalId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x1f\n" +
+	"\bcalories\x18\x04 \x01(\x05H\x01R\bcalories\x88\x01\x01\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12 \n" +
+	"\vingredients\x18\x06 \x03(\tR\vingredients\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescriptionB\x0e\n" +
+	"\f_external_idB\v\n" +
+	"\t_calories\"\xa0\x02\n" +
+	"\x11UpdateMenuRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x1f\n" +
+	"\bcalories\x18\x04 \x01(\x05H\x00R\bcalories\x88\x01\x01\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12 \n" +
+	"\vingredients\x18\x06 \x03(\tR\vingredients\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12.\n" +
+	"\x10expected_version\x18\b \x01(\rH\x01R\x0fexpectedVersion\x88\x01\x01B\v\n" +
+	"\t_caloriesB\x13\n" +
+	"\x11_expected_version\"h\n" +
+	"\x11DeleteMenuRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\">\n" +
+	"\x12DeleteMenuResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"5\n" +
+	"\x13WatchCatalogRequest\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\tR\n" +
+	"categories\"\xb5\x02\n" +
+	"\fCatalogEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12.\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1a.menu.v1.CatalogEvent.TypeR\x04type\x12\x17\n" +
+	"\amenu_id\x18\x03 \x01(\rR\x06menuId\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\rR\aversion\x12!\n" +
+	"\x04menu\x18\x05 \x01(\v2\r.menu.v1.MenuR\x04menu\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"R\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_CREATED\x10\x01\x12\x10\n" +
+	"\fTYPE_UPDATED\x10\x02\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x032\xcc\x03\n" +
+	"\vMenuService\x121\n" +
+	"\aGetMenu\x12\x17.menu.v1.GetMenuRequest\x1a\r.menu.v1.Menu\x12B\n" +
+	"\tListMenus\x12\x19.menu.v1.ListMenusRequest\x1a\x1a.menu.v1.ListMenusResponse\x12F\n" +
+	"\vSearchMenus\x12\x1b.menu.v1.SearchMenusRequest\x1a\x1a.menu.v1.ListMenusResponse\x127\n" +
+	"\n" +
+	"CreateMenu\x12\x1a.menu.v1.CreateMenuRequest\x1a\r.menu.v1.Menu\x127\n" +
+	"\n" +
+	"UpdateMenu\x12\x1a.menu.v1.UpdateMenuRequest\x1a\r.menu.v1.Menu\x12E\n" +
+	"\n" +
+	"DeleteMenu\x12\x1a.menu.v1.DeleteMenuRequest\x1a\x1b.menu.v1.DeleteMenuResponse\x12E\n" +
+	"\fWatchCatalog\x12\x1c.menu.v1.WatchCatalogRequest\x1a\x15.menu.v1.CatalogEvent0\x01B*Z(GDGOC-API/internal/grpcapi/menupb;menupbb\x06proto3"
+
+var (
+	file_menu_v1_menu_proto_rawDescOnce sync.Once
+	file_menu_v1_menu_proto_rawDescData []byte
+)
+
+func file_menu_v1_menu_proto_rawDescGZIP() []byte {
+	file_menu_v1_menu_proto_rawDescOnce.Do(func() {
+		file_menu_v1_menu_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_menu_v1_menu_proto_rawDesc), len(file_menu_v1_menu_proto_rawDesc)))
+	})
+	return file_menu_v1_menu_proto_rawDescData
+}
+
+var file_menu_v1_menu_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_menu_v1_menu_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_menu_v1_menu_proto_goTypes = []any{
+	(CatalogEvent_Type)(0),        // 0: menu.v1.CatalogEvent.Type
+	(*Menu)(nil),                  // 1: menu.v1.Menu
+	(*MenuVariant)(nil),           // 2: menu.v1.MenuVariant
+	(*Tag)(nil),                   // 3: menu.v1.Tag
+	(*MenuImage)(nil),             // 4: menu.v1.MenuImage
+	(*GetMenuRequest)(nil),        // 5: menu.v1.GetMenuRequest
+	(*MenuFilter)(nil),            // 6: menu.v1.MenuFilter
+	(*ListMenusRequest)(nil),      // 7: menu.v1.ListMenusRequest
+	(*ListMenusResponse)(nil),     // 8: menu.v1.ListMenusResponse
+	(*SearchMenusRequest)(nil),    // 9: menu.v1.SearchMenusRequest
+	(*CreateMenuRequest)(nil),     // 10: menu.v1.CreateMenuRequest
+	(*UpdateMenuRequest)(nil),     // 11: menu.v1.UpdateMenuRequest
+	(*DeleteMenuRequest)(nil),     // 12: menu.v1.DeleteMenuRequest
+	(*DeleteMenuResponse)(nil),    // 13: menu.v1.DeleteMenuResponse
+	(*WatchCatalogRequest)(nil),   // 14: menu.v1.WatchCatalogRequest
+	(*CatalogEvent)(nil),          // 15: menu.v1.CatalogEvent
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+}
+var file_menu_v1_menu_proto_depIdxs = []int32{
+	16, // 0: menu.v1.Menu.created_at:type_name -> google.protobuf.Timestamp
+	16, // 1: menu.v1.Menu.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: menu.v1.Menu.variants:type_name -> menu.v1.MenuVariant
+	3,  // 3: menu.v1.Menu.tags:type_name -> menu.v1.Tag
+	4,  // 4: menu.v1.Menu.images:type_name -> menu.v1.MenuImage
+	6,  // 5: menu.v1.ListMenusRequest.filter:type_name -> menu.v1.MenuFilter
+	1,  // 6: menu.v1.ListMenusResponse.menus:type_name -> menu.v1.Menu
+	0,  // 7: menu.v1.CatalogEvent.type:type_name -> menu.v1.CatalogEvent.Type
+	1,  // 8: menu.v1.CatalogEvent.menu:type_name -> menu.v1.Menu
+	16, // 9: menu.v1.CatalogEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	5,  // 10: menu.v1.MenuService.GetMenu:input_type -> menu.v1.GetMenuRequest
+	7,  // 11: menu.v1.MenuService.ListMenus:input_type -> menu.v1.ListMenusRequest
+	9,  // 12: menu.v1.MenuService.SearchMenus:input_type -> menu.v1.SearchMenusRequest
+	10, // 13: menu.v1.MenuService.CreateMenu:input_type -> menu.v1.CreateMenuRequest
+	11, // 14: menu.v1.MenuService.UpdateMenu:input_type -> menu.v1.UpdateMenuRequest
+	12, // 15: menu.v1.MenuService.DeleteMenu:input_type -> menu.v1.DeleteMenuRequest
+	14, // 16: menu.v1.MenuService.WatchCatalog:input_type -> menu.v1.WatchCatalogRequest
+	1,  // 17: menu.v1.MenuService.GetMenu:output_type -> menu.v1.Menu
+	8,  // 18: menu.v1.MenuService.ListMenus:output_type -> menu.v1.ListMenusResponse
+	8,  // 19: menu.v1.MenuService.SearchMenus:output_type -> menu.v1.ListMenusResponse
+	1,  // 20: menu.v1.MenuService.CreateMenu:output_type -> menu.v1.Menu
+	1,  // 21: menu.v1.MenuService.UpdateMenu:output_type -> menu.v1.Menu
+	13, // 22: menu.v1.MenuService.DeleteMenu:output_type -> menu.v1.DeleteMenuResponse
+	15, // 23: menu.v1.MenuService.WatchCatalog:output_type -> menu.v1.CatalogEvent
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_menu_v1_menu_proto_init() }
+func file_menu_v1_menu_proto_init() {
+	if File_menu_v1_menu_proto != nil {
+		return
+	}
+	file_menu_v1_menu_proto_msgTypes[0].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[7].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[9].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[10].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_menu_v1_menu_proto_rawDesc), len(file_menu_v1_menu_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_menu_v1_menu_proto_goTypes,
+		DependencyIndexes: file_menu_v1_menu_proto_depIdxs,
+		EnumInfos:         file_menu_v1_menu_proto_enumTypes,
+		MessageInfos:      file_menu_v1_menu_proto_msgTypes,
+	}.Build()
+	File_menu_v1_menu_proto = out.File
+	file_menu_v1_menu_proto_goTypes = nil
+	file_menu_v1_menu_proto_depIdxs = nil
+}
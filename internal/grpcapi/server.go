@@ -0,0 +1,244 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/grpcapi/menupb"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// server gRPC katalog menu, memakai MenuService yang sama dengan REST & GraphQL
+type Server struct {
+	menupb.UnimplementedMenuServiceServer
+
+	service   *services.MenuService
+	analytics *services.AnalyticsService
+	events    *events.Broker
+	auth      *services.AuthService
+
+	grpc   *grpc.Server
+	health *health.Server
+	done   chan struct{}
+	stop   sync.Once
+}
+
+// analytics & broker boleh nil (pencarian tidak dicatat / WatchCatalog tidak tersedia),
+// authService nil = tanpa autentikasi. Health check & reflection ikut didaftarkan
+// (tanpa autentikasi) supaya bisa dicoba lewat grpcurl
+func NewServer(service *services.MenuService, analytics *services.AnalyticsService, broker *events.Broker, authService *services.AuthService) *Server {
+	s := &Server{
+		service:   service,
+		analytics: analytics,
+		events:    broker,
+		auth:      authService,
+		health:    health.NewServer(),
+		done:      make(chan struct{}),
+	}
+	s.grpc = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor, s.unaryAuth),
+		grpc.ChainStreamInterceptor(streamInterceptor, s.streamAuth),
+	)
+
+	menupb.RegisterMenuServiceServer(s.grpc, s)
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	s.health.SetServingStatus(menupb.MenuService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return s
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	return s.grpc.Serve(listener)
+}
+
+// tandai NOT_SERVING, tutup stream WatchCatalog lalu tunggu RPC yang berjalan selesai
+func (s *Server) Shutdown() {
+	s.stop.Do(func() {
+		s.health.Shutdown()
+		close(s.done)
+		s.grpc.GracefulStop()
+	})
+}
+
+func (s *Server) GetMenu(ctx context.Context, req *menupb.GetMenuRequest) (*menupb.Menu, error) {
+	id, err := menuID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	menu, err := s.service.GetMenuByID(id, models.MenuView{Include: include(req.GetInclude())})
+	if err != nil {
+		return nil, err
+	}
+	return toMenu(menu), nil
+}
+
+func (s *Server) ListMenus(ctx context.Context, req *menupb.ListMenusRequest) (*menupb.ListMenusResponse, error) {
+	page := pageParams(req.GetSort(), req.GetPageSize(), req.GetPageToken(), req.GetWithTotal())
+	filter := req.GetFilter()
+	filters := models.MenuFilters{
+		Query:       filter.GetQ(),
+		Category:    filter.GetCategory(),
+		MinPrice:    filter.GetMinPrice(),
+		MaxPrice:    filter.GetMaxPrice(),
+		MaxCalories: int(filter.GetMaxCalories()),
+		Filter:      filter.GetExpr(),
+		PerPage:     page.PerPage,
+		Sort:        page.Sort,
+		Cursor:      page.Cursor,
+		CursorMode:  page.CursorMode,
+		WithTotal:   page.WithTotal,
+		Include:     include(req.GetInclude()),
+	}
+
+	menus, pagination, err := s.service.GetAllMenus(filters)
+	if err != nil {
+		return nil, err
+	}
+	return toListResponse(menus, pagination), nil
+}
+
+func (s *Server) SearchMenus(ctx context.Context, req *menupb.SearchMenusRequest) (*menupb.ListMenusResponse, error) {
+	start := time.Now()
+	menus, pagination, err := s.service.SearchMenus(req.GetQuery(), pageParams(req.GetSort(), req.GetPageSize(), req.GetPageToken(), req.GetWithTotal()))
+	if err != nil {
+		return nil, err
+	}
+
+	resultCount := len(menus)
+	if pagination != nil && pagination.Total != nil {
+		resultCount = int(*pagination.Total)
+	}
+	if s.analytics != nil {
+		s.analytics.Record(models.SearchSourceSearch, req.GetQuery(), resultCount, time.Since(start))
+	}
+	return toListResponse(menus, pagination), nil
+}
+
+func (s *Server) CreateMenu(ctx context.Context, req *menupb.CreateMenuRequest) (*menupb.Menu, error) {
+	menu, err := s.service.CreateMenu(models.CreateMenuRequest{
+		ExternalID:  req.ExternalId,
+		Name:        req.GetName(),
+		Category:    req.GetCategory(),
+		Calories:    intPtr(req.Calories),
+		Price:       req.GetPrice(),
+		Ingredients: req.GetIngredients(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toMenu(menu), nil
+}
+
+func (s *Server) UpdateMenu(ctx context.Context, req *menupb.UpdateMenuRequest) (*menupb.Menu, error) {
+	id, err := menuID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	version, err := expectedVersion(req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	menu, err := s.service.UpdateMenu(ctx, id, models.UpdateMenuRequest{
+		Name:        req.GetName(),
+		Category:    req.GetCategory(),
+		Calories:    intPtr(req.Calories),
+		Price:       req.GetPrice(),
+		Ingredients: req.GetIngredients(),
+		Description: req.GetDescription(),
+	}, version)
+	if err != nil {
+		return nil, err
+	}
+	return toMenu(menu), nil
+}
+
+func (s *Server) DeleteMenu(ctx context.Context, req *menupb.DeleteMenuRequest) (*menupb.DeleteMenuResponse, error) {
+	id, err := menuID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	version, err := expectedVersion(req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteMenu(id, version); err != nil {
+		return nil, err
+	}
+	return &menupb.DeleteMenuResponse{Id: req.GetId(), Message: i18n.T(language(ctx), i18n.MsgMenuDeleted)}, nil
+}
+
+// kirim perubahan katalog sampai client berhenti, server shutdown, atau stream tertinggal
+func (s *Server) WatchCatalog(req *menupb.WatchCatalogRequest, stream menupb.MenuService_WatchCatalogServer) error {
+	if s.events == nil {
+		return status.Error(codes.Unimplemented, "WatchCatalog tidak tersedia")
+	}
+
+	categories := make(map[string]bool, len(req.GetCategories()))
+	for _, category := range req.GetCategories() {
+		categories[category] = true
+	}
+
+	sub := s.events.Subscribe(0)
+	defer sub.Close()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, i18n.T(language(ctx), i18n.MsgServerShutdown))
+		case event, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, i18n.T(language(ctx), i18n.MsgWatchLagged))
+			}
+			if len(categories) > 0 && (event.Menu == nil || !categories[event.Menu.Category]) {
+				continue
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// id menu wajib diisi (0 tidak valid), sama seperti path param REST
+func menuID(id uint32) (uint, error) {
+	if id == 0 {
+		return 0, services.Invalid(services.CodeInvalidID, i18n.MsgInvalidID)
+	}
+	return uint(id), nil
+}
+
+// versi menu yang diharapkan, wajib kalau REQUIRE_IF_MATCH aktif
+func expectedVersion(version *uint32) (*uint, error) {
+	if version == nil {
+		if cfg := config.GetConfig(); cfg != nil && cfg.RequireIfMatch {
+			return nil, services.Errorf(services.KindPreconditionRequired, services.CodeIfMatchRequired, i18n.MsgFieldRequired, "expected_version")
+		}
+		return nil, nil
+	}
+	return uintPtr(version), nil
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"GDGOC-API/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsHandler struct {
+	service *services.AnalyticsService
+}
+
+// create instance baru AnalyticsHandler
+func NewAnalyticsHandler(service *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// GET laporan pencarian (top query & zero-result per hari)
+func (h *AnalyticsHandler) SearchReport(c *fiber.Ctx) error {
+	report, err := h.service.Report(
+		c.Query("from"),
+		c.Query("to"),
+		c.Query("source"),
+		parseInt(c.Query("limit")),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
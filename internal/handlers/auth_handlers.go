@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuthHandler struct {
+	service *services.AuthService
+}
+
+// create instance baru AuthHandler
+func NewAuthHandler(service *services.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// POST tukar username & password dengan access token
+func (h *AuthHandler) Token(c *fiber.Ctx) error {
+	var req models.TokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return bodyError(err)
+	}
+
+	token, err := h.service.IssueToken(req)
+	if err != nil {
+		return err
+	}
+	// token tidak boleh disimpan cache (RFC 6749 5.1)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(token)
+}
+
+// GET principal request ini
+func (h *AuthHandler) Me(c *fiber.Ctx) error {
+	p := principal(c)
+	if p == nil {
+		return services.ErrUnauthorized
+	}
+	return c.Status(fiber.StatusOK).JSON(models.PrincipalResponse{
+		Type:        p.Type,
+		ID:          p.ID,
+		Subject:     p.Subject,
+		Name:        p.Name,
+		Roles:       nonNil(p.Roles),
+		Permissions: nonNil(p.Permissions),
+	})
+}
+
+// POST buat API key, key lengkap hanya dikirim di response ini
+func (h *AuthHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req models.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return bodyError(err)
+	}
+
+	apiKey, key, err := h.service.CreateAPIKey(req, principal(c))
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusCreated).JSON(models.APIKeyCreatedResponse{
+		Message: message(c, i18n.MsgAPIKeyCreated),
+		Data:    *apiKey,
+		Key:     key,
+	})
+}
+
+// GET semua API key (tanpa key & hash)
+func (h *AuthHandler) ListAPIKeys(c *fiber.Ctx) error {
+	keys, err := h.service.ListAPIKeys()
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.APIKeyListResponse{Data: nonNil(keys)})
+}
+
+// DELETE cabut API key
+func (h *AuthHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	if _, err := h.service.RevokeAPIKey(id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.MessageResponse{Message: message(c, i18n.MsgAPIKeyRevoked)})
+}
+
+// GET role bawaan beserta permission-nya
+func (h *AuthHandler) ListRoles(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(models.RoleListResponse{Data: h.service.ListRoles()})
+}
+
+// GET role assignment, bisa difilter ?principal=user:3
+func (h *AuthHandler) ListRoleAssignments(c *fiber.Ctx) error {
+	assignments, err := h.service.ListRoleAssignments(c.Query("principal"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.RoleAssignmentListResponse{Data: nonNil(assignments)})
+}
+
+// POST beri role ke user / API key
+func (h *AuthHandler) AssignRole(c *fiber.Ctx) error {
+	var req models.CreateRoleAssignmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return bodyError(err)
+	}
+
+	assignment, err := h.service.AssignRole(req, principal(c))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(models.RoleAssignmentResponse{
+		Message: message(c, i18n.MsgRoleAssigned),
+		Data:    *assignment,
+	})
+}
+
+// DELETE cabut role assignment
+func (h *AuthHandler) UnassignRole(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	assignment, err := h.service.UnassignRole(id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.RoleAssignmentResponse{
+		Message: message(c, i18n.MsgRoleUnassigned),
+		Data:    *assignment,
+	})
+}
+
+// principal dari middleware Authenticate, nil kalau tidak terautentikasi
+func principal(c *fiber.Ctx) *auth.Principal {
+	p, _ := c.Locals(auth.LocalsKey).(*auth.Principal)
+	return p
+}
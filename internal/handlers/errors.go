@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/services"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// content type response error (RFC 7807)
+const MIMEProblemJSON = "application/problem+json"
+
+// prefix URI "type" di problem, diikuti kode error
+const ProblemTypePrefix = "/problems/"
+
+// ubah error dari handler/service jadi problem+json
+func NewProblem(c *fiber.Ctx, err error) models.Problem {
+	var domain *services.Error
+
+	// error bawaan fiber (404 route, 405, body terlalu besar, ...)
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		domain = &services.Error{Code: fiberCode(fiberErr.Code), Message: fiberErr.Message}
+		return problem(c, fiberErr.Code, domain)
+	}
+
+	domain = services.Classify(err).Localize(language(c))
+	status := domain.Status()
+	p := problem(c, status, domain)
+
+	var duplicate *services.DuplicateMenuError
+	if errors.As(err, &duplicate) {
+		p.ExistingID = duplicate.ExistingID
+	}
+	var permission *services.PermissionError
+	if errors.As(err, &permission) {
+		p.MissingPermission = permission.Permission
+	}
+
+	// detail error internal tidak dikirim ke client di production
+	if status >= fiber.StatusInternalServerError && config.GetConfig() != nil && config.GetConfig().AppEnv == "production" {
+		p.Detail = i18n.T(language(c), i18n.MsgInternalHidden)
+	}
+	return p
+}
+
+func problem(c *fiber.Ctx, status int, domain *services.Error) models.Problem {
+	return models.Problem{
+		Type:     ProblemTypePrefix + domain.Code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   domain.Message,
+		Instance: c.OriginalURL(),
+		Code:     domain.Code,
+		Errors:   domain.Fields,
+	}
+}
+
+// kode stabil untuk status dari fiber.Error, contoh 405 -> method_not_allowed
+func fiberCode(status int) string {
+	if status == fiber.StatusNotFound {
+		return services.CodeNotFound
+	}
+	if status >= fiber.StatusInternalServerError {
+		return services.CodeInternal
+	}
+	text := http.StatusText(status)
+	if text == "" {
+		return services.CodeInvalidRequest
+	}
+	return strings.ToLower(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
+
+// 400 ID di path bukan angka
+func invalidIDError() error {
+	return services.Invalid(services.CodeInvalidID, i18n.MsgInvalidID)
+}
+
+// 400 body request tidak bisa di-parse
+func bodyError(err error) error {
+	return services.Invalid(services.CodeInvalidBody, i18n.MsgInvalidBody, err)
+}
+
+// 400 field wajib kosong (di luar validator struct)
+func requiredError(c *fiber.Ctx, field string) error {
+	validation := services.Errorf(services.KindValidation, services.CodeValidation, i18n.MsgValidationFailed)
+	validation.Fields = []models.FieldError{{
+		Field:   field,
+		Rule:    "required",
+		Message: i18n.T(language(c), i18n.MsgFieldRequired, field),
+	}}
+	return validation
+}
+
+// bahasa response: hasil middleware Language, fallback ke Accept-Language
+func language(c *fiber.Ctx) string {
+	if lang, ok := c.Locals(i18n.LocalsKey).(string); ok {
+		return lang
+	}
+	return i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage), i18n.Default())
+}
+
+// pesan dari katalog dalam bahasa request
+func message(c *fiber.Ctx, key string, args ...interface{}) string {
+	return i18n.T(language(c), key, args...)
+}
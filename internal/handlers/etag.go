@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/config"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/services"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PUT/PATCH/DELETE tanpa If-Match saat REQUIRE_IF_MATCH aktif
+var errPreconditionRequired = services.Errorf(services.KindPreconditionRequired, services.CodeIfMatchRequired, i18n.MsgIfMatchRequired)
+
+// ETag menu dari id & versi (strong, view_count tidak ikut di body response menu)
+func menuETag(menu *models.Menu) string {
+	return fmt.Sprintf(`"%d-%d"`, menu.ID, menu.Version)
+}
+
+// set header ETag di response
+func setMenuETag(c *fiber.Ctx, menu *models.Menu) {
+	c.Set(fiber.HeaderETag, menuETag(menu))
+}
+
+// versi yang diharapkan dari If-Match, nil = tanpa syarat (header kosong atau "*")
+func ifMatchVersion(c *fiber.Ctx, id uint) (*uint, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		if cfg := config.GetConfig(); cfg != nil && cfg.RequireIfMatch {
+			return nil, errPreconditionRequired
+		}
+		return nil, nil
+	}
+	if header == "*" {
+		return nil, nil
+	}
+
+	// If-Match pakai perbandingan strong: ETag weak (W/...) tidak pernah cocok
+	for _, tag := range strings.Split(header, ",") {
+		if tagID, version, weak, ok := parseMenuETag(tag); ok && !weak && tagID == id {
+			return &version, nil
+		}
+	}
+
+	// tidak ada ETag yang cocok, versi 0 tidak pernah dipakai
+	var none uint
+	return &none, nil
+}
+
+// cek If-None-Match, true kalau client sudah punya versi terbaru
+func notModified(c *fiber.Ctx, menu *models.Menu) bool {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfNoneMatch))
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	// If-None-Match pakai perbandingan weak: W/"12-3" sama dengan "12-3"
+	for _, tag := range strings.Split(header, ",") {
+		if tagID, version, _, ok := parseMenuETag(tag); ok && tagID == menu.ID && version == menu.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// parsing "<id>-<version>" atau W/"<id>-<version>", weak true untuk yang kedua
+func parseMenuETag(tag string) (id uint, version uint, weak bool, ok bool) {
+	tag = strings.TrimSpace(tag)
+	if strings.HasPrefix(tag, "W/") {
+		tag, weak = tag[2:], true
+	}
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, 0, false, false
+	}
+
+	parts := strings.Split(tag[1:len(tag)-1], "-")
+	if len(parts) != 2 {
+		return 0, 0, false, false
+	}
+	parsedID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, 0, false, false
+	}
+	parsedVersion, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, 0, false, false
+	}
+	return uint(parsedID), uint(parsedVersion), weak, true
+}
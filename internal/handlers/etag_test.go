@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"testing"
+
+	"GDGOC-API/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+func withHeader(t *testing.T, name, value string, fn func(c *fiber.Ctx)) {
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	c.Request().Header.Set(name, value)
+	fn(c)
+}
+
+func TestIfMatchVersionStrongComparison(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   *uint
+	}{
+		{name: "strong cocok", header: `"12-3"`, want: uintPtr(3)},
+		{name: "salah satu dari daftar", header: `"11-1", "12-4"`, want: uintPtr(4)},
+		{name: "weak tidak pernah cocok", header: `W/"12-3"`, want: uintPtr(0)},
+		{name: "id lain", header: `"13-3"`, want: uintPtr(0)},
+		{name: "tanpa tanda kutip", header: `12-3`, want: uintPtr(0)},
+		{name: "wildcard", header: `*`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withHeader(t, fiber.HeaderIfMatch, tt.header, func(c *fiber.Ctx) {
+				got, err := ifMatchVersion(c, 12)
+				if err != nil {
+					t.Fatalf("ifMatchVersion: %v", err)
+				}
+				if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+					t.Fatalf("ifMatchVersion(%s) = %v, want %v", tt.header, deref(got), deref(tt.want))
+				}
+			})
+		})
+	}
+}
+
+func TestNotModifiedWeakComparison(t *testing.T) {
+	menu := &models.Menu{ID: 12, Version: 3}
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: `"12-3"`, want: true},
+		{header: `W/"12-3"`, want: true},
+		{header: `"12-2"`, want: false},
+		{header: `*`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			withHeader(t, fiber.HeaderIfNoneMatch, tt.header, func(c *fiber.Ctx) {
+				if got := notModified(c, menu); got != tt.want {
+					t.Fatalf("notModified(%s) = %v, want %v", tt.header, got, tt.want)
+				}
+			})
+		})
+	}
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func deref(v *uint) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/services"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// komentar SSE supaya koneksi idle tidak diputus proxy & client yang pergi terdeteksi
+	sseHeartbeat = 15 * time.Second
+	// jeda reconnect EventSource (ms)
+	sseRetry    = 3000
+	wsPing      = 30 * time.Second
+	wsWriteWait = 10 * time.Second
+
+	feedLocalsKey = "catalogFeed"
+)
+
+// feed perubahan katalog lewat SSE & WebSocket
+type EventHandler struct {
+	broker *events.Broker
+	ws     fiber.Handler
+	done   chan struct{}
+	stop   sync.Once
+}
+
+// create instance baru EventHandler
+func NewEventHandler(broker *events.Broker) *EventHandler {
+	h := &EventHandler{broker: broker, done: make(chan struct{})}
+	h.ws = websocket.New(h.serveWebSocket)
+	return h
+}
+
+// tutup semua stream yang terbuka, dipanggil sebelum server shutdown
+// (shutdown menunggu koneksi selesai, stream tidak pernah selesai sendiri)
+func (h *EventHandler) Close() {
+	h.stop.Do(func() { close(h.done) })
+}
+
+// filter & posisi resume dari request
+type feedRequest struct {
+	categories map[string]bool
+	last       events.Cursor
+	resume     bool
+	lang       string
+}
+
+func (f feedRequest) match(event events.Event) bool {
+	return len(f.categories) == 0 || (event.Menu != nil && f.categories[event.Menu.Category])
+}
+
+// category=foods,drinks; last event ID ("<epoch>-<id>") dari header Last-Event-ID
+// (reconnect EventSource) atau query last_event_id
+func parseFeedRequest(c *fiber.Ctx) (feedRequest, error) {
+	req := feedRequest{categories: make(map[string]bool), lang: language(c)}
+	for _, category := range strings.Split(c.Query("category"), ",") {
+		if category = strings.TrimSpace(category); category != "" {
+			req.categories[category] = true
+		}
+	}
+
+	lastID := c.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("last_event_id")
+	}
+	if lastID != "" {
+		cursor, err := events.ParseCursor(lastID)
+		if err != nil {
+			return req, services.Invalid(services.CodeInvalidRequest, i18n.MsgInvalidEventID)
+		}
+		req.last, req.resume = cursor, true
+	}
+	return req, nil
+}
+
+// subscribe ke broker; backlog = event terlewat, reset != nil kalau client perlu ambil ulang data
+func (h *EventHandler) subscribe(req feedRequest) (*events.Subscription, []events.Event, *models.CatalogFeedReset) {
+	if !req.resume {
+		return h.broker.Subscribe(0), nil, nil
+	}
+	sub, backlog, ok := h.broker.Resume(req.last, 0)
+	if !ok {
+		return sub, nil, &models.CatalogFeedReset{Type: models.CatalogFeedResetType, LastEventID: sub.StartCursor().String()}
+	}
+	return sub, backlog, nil
+}
+
+// GET stream SSE perubahan katalog. Nama event = jenis event (menu.created, menu.updated,
+// menu.deleted), stream yang tertinggal diputus dan EventSource otomatis reconnect
+// dengan Last-Event-ID
+func (h *EventHandler) Stream(c *fiber.Ctx) error {
+	req, err := parseFeedRequest(c)
+	if err != nil {
+		return err
+	}
+	sub, backlog, reset := h.subscribe(req)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		fmt.Fprintf(w, "retry: %d\n\n", sseRetry)
+		if reset != nil {
+			if writeSSE(w, reset.LastEventID, models.CatalogFeedResetType, reset) != nil {
+				return
+			}
+		}
+		for _, event := range backlog {
+			if req.match(event) && writeSSE(w, event.Cursor, string(event.Type), event) != nil {
+				return
+			}
+		}
+		if w.Flush() != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(sseHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-h.done:
+				return
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+				if w.Flush() != nil {
+					return
+				}
+			case event, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				if req.match(event) && writeSSE(w, event.Cursor, string(event.Type), event) != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func writeSSE(w *bufio.Writer, id string, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", id, name, payload)
+	return w.Flush()
+}
+
+// GET upgrade ke WebSocket, setiap pesan berisi satu event JSON (atau pesan reset).
+// Stream yang tertinggal ditutup dengan close code 1013, client reconnect dengan last_event_id
+func (h *EventHandler) WebSocket(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+	req, err := parseFeedRequest(c)
+	if err != nil {
+		return err
+	}
+	c.Locals(feedLocalsKey, req)
+	return h.ws(c)
+}
+
+func (h *EventHandler) serveWebSocket(conn *websocket.Conn) {
+	req, _ := conn.Locals(feedLocalsKey).(feedRequest)
+	sub, backlog, reset := h.subscribe(req)
+	defer sub.Close()
+
+	// pesan dari client diabaikan, loop baca hanya untuk mendeteksi koneksi ditutup
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(v interface{}) bool {
+		conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		return conn.WriteJSON(v) == nil
+	}
+	closeWith := func(code int, text string) {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(wsWriteWait))
+	}
+
+	if reset != nil && !send(reset) {
+		return
+	}
+	for _, event := range backlog {
+		if req.match(event) && !send(event) {
+			return
+		}
+	}
+
+	ping := time.NewTicker(wsPing)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-h.done:
+			closeWith(websocket.CloseGoingAway, i18n.T(req.lang, i18n.MsgServerShutdown))
+			return
+		case <-ping.C:
+			if conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)) != nil {
+				return
+			}
+		case event, ok := <-sub.C:
+			if !ok {
+				closeWith(websocket.CloseTryAgainLater, i18n.T(req.lang, i18n.MsgWatchLagged))
+				return
+			}
+			if req.match(event) && !send(event) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"GDGOC-API/internal/graphql"
+	"GDGOC-API/internal/models"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GraphQLHandler struct {
+	server *graphql.Server
+}
+
+// create instance baru GraphQLHandler
+func NewGraphQLHandler(server *graphql.Server) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// POST query/mutation GraphQL. Error query (parse, validasi, batas depth/complexity,
+// resolver) dikirim di field errors dengan status 200 sesuai konvensi GraphQL
+func (h *GraphQLHandler) Query(c *fiber.Ctx) error {
+	var req models.GraphQLRequest
+	if err := c.BodyParser(&req); err != nil {
+		return bodyError(err)
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return requiredError(c, "query")
+	}
+
+	result := h.server.Execute(graphql.WithLanguage(c.UserContext(), language(c)), req)
+	return c.Status(fiber.StatusOK).JSON(result)
+}
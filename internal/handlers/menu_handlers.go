@@ -1,26 +1,33 @@
 package handlers
 
 import(
+	"bufio"
+	"GDGOC-API/internal/catalog"
 	"GDGOC-API/internal/gemini"
+	"GDGOC-API/internal/i18n"
 	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/patch"
 	"GDGOC-API/internal/services"
 	"strconv"
 	"strings"
 	"fmt"
 	"log"
+	"time"
 	"github.com/gofiber/fiber/v2"
 )
 
 type MenuHandler struct{
 	service *services.MenuService
 	geminiService	*gemini.Service
+	analytics	*services.AnalyticsService
 }
 
 // create instance baru MenuHandler
-func NewMenuHandler(service *services.MenuService, geminiService *gemini.Service) *MenuHandler{
+func NewMenuHandler(service *services.MenuService, geminiService *gemini.Service, analytics *services.AnalyticsService) *MenuHandler{
 	return &MenuHandler{
 		service: service,
 		geminiService: geminiService,
+		analytics: analytics,
 	}
 }
 
@@ -30,19 +37,26 @@ func (h *MenuHandler) GetRecommendations(c *fiber.Ctx) error {
     
     // Parse request body
     if err := c.BodyParser(&req); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-            Message: "Invalid request body",
-            Errors:  err.Error(),
-        })
+        return bodyError(err)
     }
 
     // query tidak boleh kosong
     if strings.TrimSpace(req.Query) == "" {
-        return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-            Message: "Query is required",
-        })
+        return requiredError(c, "query")
+    }
+
+    result, err := h.Recommend(language(c), req)
+    if err != nil {
+        return err
     }
 
+    return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// rekomendasi dari Gemini (fallback ke rekomendasi basic), dipakai REST & GraphQL
+func (h *MenuHandler) Recommend(lang string, req gemini.RecommendationReq) (*gemini.RecommendationResult, error) {
+    start := time.Now()
+
     // Dapatkan semua menu yang tersedia (dengan filter basic)
     filters := models.MenuFilters{
         MaxPrice: req.MaxPrice,
@@ -50,10 +64,7 @@ func (h *MenuHandler) GetRecommendations(c *fiber.Ctx) error {
     
     allMenus, _, err := h.service.GetAllMenus(filters)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-            Message: "Failed to get menus",
-            Errors:  err.Error(),
-        })
+        return nil, err
     }
 
     // Filter manual untuk diet
@@ -67,14 +78,16 @@ func (h *MenuHandler) GetRecommendations(c *fiber.Ctx) error {
         if err != nil {
             log.Printf("Gemini recommendation failed: %v", err)
             // Fallback ke basic recommendations
-            result = h.getBasicRecommendations(req, filteredMenus)
+            result = h.getBasicRecommendations(lang, req, filteredMenus)
         }
     } else {
         // menggunakan basic recommendations (jika Gemini tidak available)
-        result = h.getBasicRecommendations(req, filteredMenus)
+        result = h.getBasicRecommendations(lang, req, filteredMenus)
     }
 
-    return c.Status(fiber.StatusOK).JSON(result)
+    h.recordSearch(models.SearchSourceRecommendation, req.Query, len(result.Recommendations), start)
+
+    return result, nil
 }
 
 // Filter menu berdasarkan dietary restrictions
@@ -159,7 +172,7 @@ func (h *MenuHandler) containsAny(ingredients []string, keywords []string) bool
 }
 
 // Fallback recommendations tanpa AI
-func (h *MenuHandler) getBasicRecommendations(req gemini.RecommendationReq, menus []models.Menu) *gemini.RecommendationResult {
+func (h *MenuHandler) getBasicRecommendations(lang string, req gemini.RecommendationReq, menus []models.Menu) *gemini.RecommendationResult {
     var recommendations []gemini.MenuRecommendation
     
     // Simple keyword matching
@@ -170,16 +183,16 @@ func (h *MenuHandler) getBasicRecommendations(req gemini.RecommendationReq, menu
             break
         }
         
-        reason := " Rekomendasi berdasarkan preferensi Anda"
+        reason := " " + i18n.T(lang, i18n.MsgRecommended)
         menuText := strings.ToLower(menu.Name + " " + menu.Description)
         
         // Simple keyword matching untuk reason yang lebih spesifik
         if strings.Contains(queryLower, "pedas") && strings.Contains(menuText, "pedas") {
-            reason = " Pedas sesuai permintaan Anda"
+            reason = " " + i18n.T(lang, i18n.MsgSpicy)
         } else if strings.Contains(queryLower, "sehat") && (menu.Calories != nil && *menu.Calories < 400) {
-            reason = " Sehat dengan kalori terkontrol"
+            reason = " " + i18n.T(lang, i18n.MsgHealthy)
         } else if strings.Contains(queryLower, "murah") && menu.Price < 30000 {
-            reason = " Harga terjangkau"
+            reason = " " + i18n.T(lang, i18n.MsgAffordable)
         }
         
         recommendations = append(recommendations, gemini.MenuRecommendation{
@@ -191,7 +204,7 @@ func (h *MenuHandler) getBasicRecommendations(req gemini.RecommendationReq, menu
     return &gemini.RecommendationResult{
         Query:          req.Query,
         Recommendations: recommendations,
-        SearchSummary:  fmt.Sprintf("Ditemukan %d rekomendasi untuk '%s'", len(recommendations), req.Query),
+        SearchSummary:  i18n.T(lang, i18n.MsgRecommendSum, len(recommendations), req.Query),
     }
 }
 
@@ -202,91 +215,154 @@ func (h *MenuHandler) CreateMenu(c *fiber.Ctx) error{
 
 	//parsing
 	if err := c.BodyParser(&req); err != nil{
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Message: "Invalid request body",
-			Errors: err.Error(),
-		})
+		return bodyError(err)
 	}
 
 	menu, err := h.service.CreateMenu(req)
 	if err != nil{
-		if strings.Contains(err.Error(), "validation") || strings.Contains(err.Error(), "required"){
-			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-				Message: "Validation failed",
-				Errors: err.Error(),
-			})
-		}
-
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Message: "gagal membuat menu",
-			Errors: err.Error(),
-		})
+		return err
 	}
 
 	// return sukses
+	setMenuETag(c, menu)
 	return c.Status(fiber.StatusCreated).JSON(models.MenuResponse{
-		Message: "Menu berhasil dibuat",
-		Data: *menu,
+		Message: message(c, i18n.MsgMenuCreated),
+		Data: versionedMenu(menu, models.MenuView{}),
+	})
+}
+
+// POST bulk create/update/delete
+func (h *MenuHandler) BulkMenus(c *fiber.Ctx) error{
+	var req models.BulkRequest
+	if err := c.BodyParser(&req); err != nil{
+		return bodyError(err)
+	}
+
+	result, err := h.service.BulkApply(c.UserContext(), req)
+	if err != nil{
+		return err
+	}
+
+	// atomic gagal = tidak ada yang tersimpan, best effort sebagian gagal = multi-status
+	status := fiber.StatusOK
+	if result.Failed > 0{
+		status = fiber.StatusMultiStatus
+		if result.Mode == models.BulkModeAtomic{
+			status = fiber.StatusUnprocessableEntity
+		}
+	}
+	return c.Status(status).JSON(result)
+}
+
+// POST import katalog menu dari file CSV/XLSX/JSONL (multipart, field "file")
+func (h *MenuHandler) ImportMenus(c *fiber.Ctx) error{
+	fileHeader, err := c.FormFile("file")
+	if err != nil{
+		return services.Invalid(services.CodeInvalidRequest, i18n.MsgImportFileRequired)
+	}
+
+	format, err := catalog.DetectFormat(fileHeader.Filename, c.Query("format"))
+	if err != nil{
+		return services.Invalid(services.CodeInvalidRequest, i18n.MsgImportFormat)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil{
+		return services.Invalid(services.CodeInvalidRequest, i18n.MsgImportFileOpen, err)
+	}
+	defer file.Close()
+
+	report, err := h.service.ImportMenus(c.UserContext(), file, models.ImportOptions{
+		Format:	format,
+		DryRun:	c.QueryBool("dry_run", false),
+		UpsertBy:	c.Query("upsert_by", models.ImportUpsertByName),
 	})
+	if err != nil{
+		return err
+	}
+
+	// sebagian baris gagal = multi-status
+	status := fiber.StatusOK
+	if report.Failed > 0{
+		status = fiber.StatusMultiStatus
+	}
+	return c.Status(status).JSON(report)
 }
 
 // GET menu (filter & pagination)
 func (h *MenuHandler) GetAllMenus(c *fiber.Ctx) error{
 	//parsing
-	filters := models.MenuFilters{
-		Query:	c.Query("q"),
-		Category:	c.Query("category"),
-		MinPrice:	parseFloat(c.Query("min_price")),
-		MaxPrice:	parseFloat(c.Query("max_price")),
-		MaxCalories:	parseInt(c.Query("max_cal")),
-		Page:	parseInt(c.Query("page")),
-		PerPage: parseInt(c.Query("per_page")),
-		Sort:	c.Query("sort"),
-	}
+	filters := parseMenuFilters(c)
+	filters.Page = parseInt(c.Query("page"))
+	filters.PerPage = parseInt(c.Query("per_page"))
+	filters.Cursor, filters.CursorMode, filters.WithTotal = parseCursorParams(c)
+	filters.Fields = c.Query("fields")
+	filters.Include = c.Query("include")
 
 	menus, pagination, err := h.service.GetAllMenus(filters)
 	if err != nil{
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Message: "Gagal mengambil data menu",
-			Errors: err.Error(),
-		})
+		return err
 	}
 
 	// return
 	response := models.MenuListResponse{
-		Data:	menus,
+		Data:	projectMenus(menus, filters.View()),
 		Pagination: pagination,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// GET export semua menu hasil filter (csv, jsonl, xlsx), dikirim streaming
+func (h *MenuHandler) ExportMenus(c *fiber.Ctx) error{
+	format := strings.ToLower(c.Query("format", catalog.FormatCSV))
+
+	export, err := h.service.ExportMenus(parseMenuFilters(c), format, c.Query("columns"))
+	if err != nil{
+		return err
+	}
+
+	contentTypes := map[string]string{
+		catalog.FormatCSV:	"text/csv; charset=utf-8",
+		catalog.FormatJSONL:	"application/x-ndjson",
+		catalog.FormatXLSX:	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	}
+	c.Set(fiber.HeaderContentType, contentTypes[format])
+	c.Attachment(fmt.Sprintf("menu-export-%s.%s", time.Now().Format("20060102-150405"), format))
+
+	// status & header sudah terkirim, error di tengah stream hanya bisa di-log
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer){
+		if err := export.Stream(w); err != nil{
+			log.Printf("Export menu gagal: %v", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
 // Get menu by id
 func (h *MenuHandler) GetMenuByID(c *fiber.Ctx) error{
 	//parsing
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil{
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Message: "ID menu invalid",
-		})
+		return invalidIDError()
 	}
 
-	menu, err := h.service.GetMenuByID(uint(id))
+	view := models.MenuView{Fields: c.Query("fields"), Include: c.Query("include")}
+	menu, err := h.service.GetMenuByID(uint(id), view)
 	if err != nil{
-		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "tidak ditemukan"){
-			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Message: "Menu tidak ditemukan",
-			})
-		}
+		return err
+	}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Message: "Gagal retrieve menu",
-			Errors: err.Error(),
-		})
+	if notModified(c, menu){
+		setMenuETag(c, menu)
+		return c.SendStatus(fiber.StatusNotModified)
 	}
 
+	setMenuETag(c, menu)
+	h.service.RecordView(menu.ID)
 	return c.Status(fiber.StatusOK).JSON(models.MenuResponse{
-		Data: *menu,
+		Data: versionedMenu(menu, view),
 	})
 }
 
@@ -295,44 +371,90 @@ func (h *MenuHandler) UpdateMenu(c *fiber.Ctx) error{
 	// parsing id
 	id, err := strconv.ParseUint(c.Params("id"),10, 32)
 	if err != nil{
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Message: "ID menu invalid",
-		})
+		return invalidIDError()
+	}
+
+	expectedVersion, err := ifMatchVersion(c, uint(id))
+	if err != nil{
+		return err
 	}
 
 	var req models.UpdateMenuRequest
 	if err := c.BodyParser(&req); err != nil{
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Message: "Invalid request body",
-			Errors: err.Error(),
-		})
+		return bodyError(err)
 	}
 
-	menu, err := h.service.UpdateMenu(uint(id), req)
+	menu, err := h.service.UpdateMenu(c.UserContext(), uint(id), req, expectedVersion)
 	if err != nil{
-		if strings.Contains(err.Error(), "tidak ditemukan") || strings.Contains(err.Error(), "not found"){
-			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Message: "Menu tidak ditemukan",
-			})
-		}
+		return err
+	}
 
-		if strings.Contains(err.Error(), "validation") || strings.Contains(err.Error(), "required"){
-			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-				Message: "Validasi gagal",
-				Errors: err.Error(),
-			})
-		}
+	// return response
+	setMenuETag(c, menu)
+	return c.Status(fiber.StatusOK).JSON(models.MenuResponse{
+		Message: message(c, i18n.MsgMenuUpdated),
+		Data: versionedMenu(menu, models.MenuView{}),
+	})
+}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Message: "Gagal update menu",
-			Errors: err.Error(),
-		})
+// ganti varian, tag & gambar menu
+func (h *MenuHandler) UpdateMenuRelations(c *fiber.Ctx) error{
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil{
+		return invalidIDError()
 	}
 
-	// return response
+	expectedVersion, err := ifMatchVersion(c, uint(id))
+	if err != nil{
+		return err
+	}
+
+	var req models.UpdateMenuRelationsRequest
+	if err := c.BodyParser(&req); err != nil{
+		return bodyError(err)
+	}
+
+	menu, err := h.service.UpdateMenuRelations(c.UserContext(), uint(id), req, expectedVersion)
+	if err != nil{
+		return err
+	}
+
+	setMenuETag(c, menu)
 	return c.Status(fiber.StatusOK).JSON(models.MenuResponse{
-		Message: "Menu berhasil diupdate",
-		Data: *menu,
+		Message: message(c, i18n.MsgMenuUpdated),
+		Data: versionedMenu(menu, models.MenuView{Include: "variants,tags,images"}),
+	})
+}
+
+// PATCH menu (merge patch / JSON patch)
+func (h *MenuHandler) PatchMenu(c *fiber.Ctx) error{
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil{
+		return invalidIDError()
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0]))
+	switch contentType{
+	case patch.ContentTypeMergePatch, patch.ContentTypeJSONPatch, fiber.MIMEApplicationJSON:
+	default:
+		return services.Errorf(services.KindUnsupportedMediaType, services.CodeUnsupportedMediaType,
+			i18n.MsgPatchContentType, patch.ContentTypeMergePatch, patch.ContentTypeJSONPatch)
+	}
+
+	expectedVersion, err := ifMatchVersion(c, uint(id))
+	if err != nil{
+		return err
+	}
+
+	menu, err := h.service.PatchMenu(c.UserContext(), uint(id), c.Body(), contentType, expectedVersion)
+	if err != nil{
+		return err
+	}
+
+	setMenuETag(c, menu)
+	return c.Status(fiber.StatusOK).JSON(models.MenuResponse{
+		Message: message(c, i18n.MsgMenuUpdated),
+		Data: versionedMenu(menu, models.MenuView{}),
 	})
 }
 
@@ -341,28 +463,22 @@ func (h *MenuHandler) DeleteMenu(c *fiber.Ctx) error{
 	// parsing ID
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil{
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Message: "ID menu invalid",
-		})
+		return invalidIDError()
 	}
 
-	err = h.service.DeleteMenu(uint(id))
+	expectedVersion, err := ifMatchVersion(c, uint(id))
 	if err != nil{
-		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "tidak ditemukan"){
-			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Message: "Menu tidak ditemukan",
-			})
-		}
+		return err
+	}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Message: "Gagal menghapus menu",
-			Errors: err.Error(),
-		})
+	err = h.service.DeleteMenu(uint(id), expectedVersion)
+	if err != nil{
+		return err
 	}
 
 	//return
 	return c.Status(fiber.StatusOK).JSON(models.MessageResponse{
-		Message: "Menu berhasil dihapus",
+		Message: message(c, i18n.MsgMenuDeleted),
 	})
 }
 
@@ -374,10 +490,7 @@ func (h *MenuHandler) GroupByCategory(c *fiber.Ctx) error{
 
 	result, err := h.service.GroupMenusByCategory(mode, perCategory)
 	if err != nil{
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Message: "Gagal mengelompokkan menu",
-			Errors: err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(models.GroupByCategoryResponse{
@@ -389,23 +502,108 @@ func (h *MenuHandler) GroupByCategory(c *fiber.Ctx) error{
 func (h *MenuHandler) SearchMenus(c *fiber.Ctx) error{
 	//parsing query parameter
 	query := c.Query("q")
-	page := parseInt(c.Query("page"))
-	perPage := parseInt(c.Query("per_page"))
+	params := models.PageParams{
+		Page:	parseInt(c.Query("page")),
+		PerPage:	parseInt(c.Query("per_page")),
+		Sort:	c.Query("sort"),
+	}
+	params.Cursor, params.CursorMode, params.WithTotal = parseCursorParams(c)
+	start := time.Now()
 
-	menus, pagination, err := h.service.SearchMenus(query, page, perPage)
+	menus, pagination, err := h.service.SearchMenus(query, params)
 	if err != nil{
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Message: "Gagal search menu",
-			Errors: err.Error(),
-		})
+		return err
 	}
 
+	resultCount := len(menus)
+	if pagination.Total != nil{
+		resultCount = int(*pagination.Total)
+	}
+	h.recordSearch(models.SearchSourceSearch, query, resultCount, start)
+
 	return c.Status(fiber.StatusOK).JSON(models.MenuListResponse{
 		Data: menus,
 		Pagination: pagination,
 	})
 }
 
+// semantic search
+func (h *MenuHandler) SemanticSearch(c *fiber.Ctx) error{
+	query := c.Query("q")
+	if strings.TrimSpace(query) == ""{
+		return requiredError(c, "q")
+	}
+
+	mode := c.Query("mode", services.SearchModeVector)
+	limit := parseInt(c.Query("limit"))
+	alpha := parseFloat(c.Query("alpha"))
+	start := time.Now()
+
+	results, err := h.service.SemanticSearch(query, mode, limit, alpha)
+	if err != nil{
+		return err
+	}
+
+	h.recordSearch(models.SearchSourceSemantic, query, len(results), start)
+
+	if mode != services.SearchModeHybrid{
+		mode = services.SearchModeVector
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SemanticSearchResponse{
+		Query: query,
+		Mode: mode,
+		Data: results,
+	})
+}
+
+// catat query ke analytics (kalau aktif)
+func (h *MenuHandler) recordSearch(source, query string, resultCount int, start time.Time){
+	if h.analytics == nil{
+		return
+	}
+	h.analytics.Record(source, query, resultCount, time.Since(start))
+}
+
+// GET laporan menu dengan nama mirip
+func (h *MenuHandler) NearDuplicates(c *fiber.Ctx) error{
+	report, err := h.service.NearDuplicates(
+		c.Query("category"),
+		parseFloat(c.Query("threshold")),
+		c.QueryBool("cross_category", false),
+		parseInt(c.Query("limit")),
+	)
+	if err != nil{
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// filter list menu dari query string (tanpa paging)
+func parseMenuFilters(c *fiber.Ctx) models.MenuFilters{
+	return models.MenuFilters{
+		Query:	c.Query("q"),
+		Category:	c.Query("category"),
+		MinPrice:	parseFloat(c.Query("min_price")),
+		MaxPrice:	parseFloat(c.Query("max_price")),
+		MaxCalories:	parseInt(c.Query("max_cal")),
+		Filter:	c.Query("filter"),
+		Sort:	c.Query("sort"),
+	}
+}
+
+// parsing cursor, mode cursor aktif kalau param cursor ada (boleh kosong untuk halaman pertama).
+// total default dihitung di mode offset dan dilewati di mode cursor
+func parseCursorParams(c *fiber.Ctx) (string, bool, bool){
+	cursorMode := c.Context().QueryArgs().Has("cursor")
+	withTotal := !cursorMode
+	if v, err := strconv.ParseBool(c.Query("with_total")); err == nil{
+		withTotal = v
+	}
+	return c.Query("cursor"), cursorMode, withTotal
+}
+
 // convert str -> int
 func parseInt(s string) int{
 	if s == ""{
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"GDGOC-API/internal/models"
+	"encoding/json"
+	"strings"
+)
+
+// fields= & include= dari query, fields nil = semua field
+func viewKeys(view models.MenuView) (fields map[string]bool, include []string) {
+	split := func(list string) []string {
+		var items []string
+		for _, item := range strings.Split(list, ",") {
+			if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	if items := split(view.Fields); len(items) > 0 {
+		fields = make(map[string]bool)
+		for _, item := range items {
+			fields[item] = true
+		}
+	}
+	return fields, split(view.Include)
+}
+
+// buang field yang tidak diminta (kolom tambahan untuk sort/cursor ikut terbuang),
+// relasi yang di-include selalu ada walau kosong
+func projectMenu(menu *models.Menu, view models.MenuView) interface{} {
+	fields, include := viewKeys(view)
+	if fields == nil && len(include) == 0 {
+		return menu
+	}
+	return project(menu, fields, include)
+}
+
+// menu untuk response yang membawa ETag menu: view_count dibuang karena berubah
+// tanpa ganti versi, body harus sama persis untuk ETag yang sama
+func versionedMenu(menu *models.Menu, view models.MenuView) map[string]interface{} {
+	fields, include := viewKeys(view)
+	out := project(menu, fields, include)
+	delete(out, "view_count")
+	return out
+}
+
+func projectMenus(menus []models.Menu, view models.MenuView) interface{} {
+	fields, include := viewKeys(view)
+	if fields == nil && len(include) == 0 {
+		return menus
+	}
+
+	projected := make([]map[string]interface{}, len(menus))
+	for i := range menus {
+		projected[i] = project(&menus[i], fields, include)
+	}
+	return projected
+}
+
+func project(menu *models.Menu, fields map[string]bool, include []string) map[string]interface{} {
+	data, _ := json.Marshal(menu)
+	var out map[string]interface{}
+	json.Unmarshal(data, &out)
+
+	if fields != nil {
+		for key := range out {
+			if !fields[key] {
+				delete(out, key)
+			}
+		}
+	}
+
+	// omitempty di model membuang relasi kosong, di sini dikembalikan jadi []
+	for _, relation := range include {
+		switch relation {
+		case "variants":
+			out[relation] = nonNil(menu.Variants)
+		case "tags":
+			out[relation] = nonNil(menu.Tags)
+		case "images":
+			out[relation] = nonNil(menu.Images)
+		}
+	}
+	return out
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"strconv"
+
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	service *services.WebhookService
+}
+
+// create instance baru WebhookHandler
+func NewWebhookHandler(service *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// POST buat webhook, secret hanya dikirim di response ini
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	var req models.CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return bodyError(err)
+	}
+
+	hook, secret, err := h.service.Create(req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(models.WebhookCreatedResponse{
+		Message: message(c, i18n.MsgWebhookCreated),
+		Data:    *hook,
+		Secret:  secret,
+	})
+}
+
+// GET semua webhook
+func (h *WebhookHandler) List(c *fiber.Ctx) error {
+	hooks, err := h.service.List()
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.WebhookListResponse{Data: nonNil(hooks)})
+}
+
+// GET webhook by id
+func (h *WebhookHandler) Get(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	hook, err := h.service.Get(id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.WebhookResponse{Data: *hook})
+}
+
+// PUT ganti URL, event, deskripsi & status aktif
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	var req models.UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return bodyError(err)
+	}
+
+	hook, err := h.service.Update(id, req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.WebhookResponse{
+		Message: message(c, i18n.MsgWebhookUpdated),
+		Data:    *hook,
+	})
+}
+
+// DELETE webhook beserta riwayat pengirimannya
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	if err := h.service.Delete(id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.MessageResponse{Message: message(c, i18n.MsgWebhookDeleted)})
+}
+
+// POST kirim event webhook.ping
+func (h *WebhookHandler) Ping(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	delivery, err := h.service.Ping(id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(models.WebhookDeliveryResponse{
+		Message: message(c, i18n.MsgWebhookPing),
+		Data:    *delivery,
+	})
+}
+
+// GET pengiriman satu webhook
+func (h *WebhookHandler) WebhookDeliveries(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	return h.listDeliveries(c, id)
+}
+
+// GET semua pengiriman, ?status=dead untuk daftar dead-letter
+func (h *WebhookHandler) Deliveries(c *fiber.Ctx) error {
+	return h.listDeliveries(c, uint(parseInt(c.Query("webhook_id"))))
+}
+
+func (h *WebhookHandler) listDeliveries(c *fiber.Ctx, webhookID uint) error {
+	deliveries, pagination, err := h.service.ListDeliveries(models.WebhookDeliveryFilters{
+		WebhookID: webhookID,
+		Status:    c.Query("status"),
+		Page:      parseInt(c.Query("page")),
+		PerPage:   parseInt(c.Query("per_page")),
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.WebhookDeliveryListResponse{
+		Data:       nonNil(deliveries),
+		Pagination: pagination,
+	})
+}
+
+// GET pengiriman beserta log setiap percobaan
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	delivery, err := h.service.GetDelivery(id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(models.WebhookDeliveryResponse{Data: *delivery})
+}
+
+// POST kirim ulang pengiriman (mis. dari dead-letter)
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	delivery, err := h.service.Redeliver(id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(models.WebhookDeliveryResponse{
+		Message: message(c, i18n.MsgWebhookRedelivery),
+		Data:    *delivery,
+	})
+}
+
+// id dari path param :id
+func pathID(c *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil || id == 0 {
+		return 0, services.Invalid(services.CodeInvalidID, i18n.MsgInvalidPathID)
+	}
+	return uint(id), nil
+}
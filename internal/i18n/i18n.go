@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GDGOC-API/internal/config"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+)
+
+// bahasa yang didukung (kode ISO 639-1)
+const (
+	Indonesian = "id"
+	English    = "en"
+)
+
+var Supported = []string{Indonesian, English}
+
+// key c.Locals tempat bahasa request disimpan
+const LocalsKey = "lang"
+
+// translator validator per bahasa, dipakai bersama semua instance validator
+var universal = ut.New(id.New(), id.New(), en.New())
+
+// bahasa default dari DEFAULT_LANGUAGE, fallback Indonesia
+func Default() string {
+	if cfg := config.GetConfig(); cfg != nil && IsSupported(cfg.DefaultLanguage) {
+		return cfg.DefaultLanguage
+	}
+	return Indonesian
+}
+
+func IsSupported(lang string) bool {
+	for _, l := range Supported {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// pesan dari katalog, key yang tidak ada di bahasa tsb pakai bahasa default
+func T(lang, key string, args ...interface{}) string {
+	template, ok := messages[lang][key]
+	if !ok {
+		if template, ok = messages[Default()][key]; !ok {
+			template = key
+		}
+	}
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// translator validator untuk bahasa lang
+func Translator(lang string) ut.Translator {
+	if trans, ok := universal.GetTranslator(lang); ok {
+		return trans
+	}
+	trans, _ := universal.GetTranslator(Default())
+	return trans
+}
+
+// daftarkan terjemahan pesan error validator (id & en)
+func RegisterValidator(validate *validator.Validate) error {
+	if err := id_translations.RegisterDefaultTranslations(validate, Translator(Indonesian)); err != nil {
+		return err
+	}
+	if err := en_translations.RegisterDefaultTranslations(validate, Translator(English)); err != nil {
+		return err
+	}
+
+	// tag yang belum ada di terjemahan bawaan bahasa Inggris
+	return validate.RegisterTranslation("http_url", Translator(English), func(trans ut.Translator) error {
+		return trans.Add("http_url", "{0} must be a valid HTTP/HTTPS URL", true)
+	}, func(trans ut.Translator, fe validator.FieldError) string {
+		message, _ := trans.T("http_url", fe.Field())
+		return message
+	})
+}
+
+// pilih bahasa dari header Accept-Language (q-value dihormati),
+// tidak ada yang didukung = fallback
+func Negotiate(header, fallback string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		// en-US -> en, * = bahasa default
+		lang := strings.SplitN(tag, "-", 2)[0]
+		if lang == "*" {
+			lang = fallback
+		}
+		if IsSupported(lang) {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return fallback
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].lang
+}
@@ -0,0 +1,315 @@
+package i18n
+
+// key pesan di katalog, template memakai format fmt
+const (
+	// response sukses
+	MsgAPIRunning   = "api.running"
+	MsgMenuCreated  = "menu.created"
+	MsgMenuUpdated  = "menu.updated"
+	MsgMenuDeleted  = "menu.deleted"
+	MsgRecommended  = "recommendation.default"
+	MsgSpicy        = "recommendation.spicy"
+	MsgHealthy      = "recommendation.healthy"
+	MsgAffordable   = "recommendation.affordable"
+	MsgRecommendSum = "recommendation.summary"
+
+	// error umum
+	MsgInternalHidden     = "error.internal_hidden"
+	MsgNotFound           = "error.not_found"
+	MsgEndpointNotFound   = "error.endpoint_not_found"
+	MsgInvalidID          = "error.invalid_id"
+	MsgInvalidBody        = "error.invalid_body"
+	MsgValidationFailed   = "error.validation_failed"
+	MsgFieldRequired      = "error.field_required"
+	MsgMenuNotFound       = "error.menu_not_found"
+	MsgDuplicateMenu      = "error.duplicate_menu"
+	MsgVersionMismatch    = "error.version_mismatch"
+	MsgIfMatchRequired    = "error.if_match_required"
+	MsgPatchContentType   = "error.patch_content_type"
+	MsgSemanticOff        = "error.semantic_unavailable"
+	MsgIdemKeyTooLong     = "error.idempotency_key_too_long"
+	MsgIdemMismatch       = "error.idempotency_key_mismatch"
+	MsgIdemInProgress     = "error.idempotency_key_in_progress"
+	MsgBulkMode           = "error.bulk_mode"
+	MsgBulkEmpty          = "error.bulk_empty"
+	MsgBulkTooMany        = "error.bulk_too_many"
+	MsgBulkIDRequired     = "error.bulk_id_required"
+	MsgBulkUnknownOp      = "error.bulk_unknown_op"
+	MsgBulkDataRequired   = "error.bulk_data_required"
+	MsgBulkDataInvalid    = "error.bulk_data_invalid"
+	MsgBulkRolledBack     = "error.bulk_rolled_back"
+	MsgBulkSkipped        = "error.bulk_skipped"
+	MsgDateRange          = "error.date_range"
+	MsgDateFormat         = "error.date_format"
+	MsgThresholdRange     = "error.threshold_range"
+	MsgTooManyMenus       = "error.too_many_menus"
+	MsgExportFormat       = "error.export_format"
+	MsgImportFormat       = "error.import_format"
+	MsgImportUpsertBy     = "error.import_upsert_by"
+	MsgImportFileRequired = "error.import_file_required"
+	MsgImportFileOpen     = "error.import_file_open"
+	MsgImportFile         = "error.import_file"
+	MsgImportExternalID   = "error.import_external_id"
+	MsgImportDuplicateRow = "error.import_duplicate_row"
+	MsgImportPrice        = "error.import_price"
+	MsgImportCalories     = "error.import_calories"
+	MsgInvalidColumns     = "error.invalid_columns"
+	MsgContentType        = "error.content_type"
+	MsgVersionRequired    = "error.version_required"
+	MsgQueryTooDeep       = "error.query_too_deep"
+	MsgQueryTooComplex    = "error.query_too_complex"
+	MsgWatchLagged        = "error.watch_lagged"
+	MsgServerShutdown     = "error.server_shutdown"
+	MsgInvalidEventID     = "error.invalid_event_id"
+	MsgInvalidPathID      = "error.invalid_path_id"
+	MsgRelationsEmpty     = "error.relations_empty"
+
+	// webhook
+	MsgWebhookCreated    = "webhook.created"
+	MsgWebhookUpdated    = "webhook.updated"
+	MsgWebhookDeleted    = "webhook.deleted"
+	MsgWebhookPing       = "webhook.ping"
+	MsgWebhookRedelivery = "webhook.redelivery"
+	MsgWebhookNotFound   = "error.webhook_not_found"
+	MsgDeliveryNotFound  = "error.webhook_delivery_not_found"
+	MsgWebhookInactive   = "error.webhook_inactive"
+	MsgWebhookURLBlocked = "error.webhook_url_blocked"
+
+	// auth
+	MsgAPIKeyCreated         = "auth.api_key_created"
+	MsgAPIKeyRevoked         = "auth.api_key_revoked"
+	MsgUnauthorized          = "error.unauthorized"
+	MsgInvalidToken          = "error.invalid_token"
+	MsgTokenExpired          = "error.token_expired"
+	MsgInvalidAPIKey         = "error.invalid_api_key"
+	MsgInvalidCredentials    = "error.invalid_credentials"
+	MsgTokenIssueUnavailable = "error.token_issue_unavailable"
+	MsgAPIKeyNotFound        = "error.api_key_not_found"
+
+	// role & permission
+	MsgRoleAssigned           = "rbac.role_assigned"
+	MsgRoleUnassigned         = "rbac.role_unassigned"
+	MsgForbidden              = "error.forbidden"
+	MsgUnknownRole            = "error.unknown_role"
+	MsgInvalidPrincipal       = "error.invalid_principal"
+	MsgDuplicateRole          = "error.role_already_assigned"
+	MsgRoleAssignmentNotFound = "error.role_assignment_not_found"
+	MsgLastAdmin              = "error.last_admin"
+
+	// pelanggaran schema OpenAPI per field
+	MsgSchemaType     = "validation.type"
+	MsgSchemaOneOf    = "validation.oneof"
+	MsgSchemaGT       = "validation.gt"
+	MsgSchemaGTE      = "validation.gte"
+	MsgSchemaLT       = "validation.lt"
+	MsgSchemaLTE      = "validation.lte"
+	MsgSchemaMinLen   = "validation.min_length"
+	MsgSchemaMaxLen   = "validation.max_length"
+	MsgSchemaMinItems = "validation.min_items"
+	MsgSchemaMaxItems = "validation.max_items"
+)
+
+var messages = map[string]map[string]string{
+	Indonesian: {
+		MsgAPIRunning:   "menu API berjalan",
+		MsgMenuCreated:  "Menu berhasil dibuat",
+		MsgMenuUpdated:  "Menu berhasil diupdate",
+		MsgMenuDeleted:  "Menu berhasil dihapus",
+		MsgRecommended:  "Rekomendasi berdasarkan preferensi Anda",
+		MsgSpicy:        "Pedas sesuai permintaan Anda",
+		MsgHealthy:      "Sehat dengan kalori terkontrol",
+		MsgAffordable:   "Harga terjangkau",
+		MsgRecommendSum: "Ditemukan %d rekomendasi untuk '%s'",
+
+		MsgInternalHidden:     "terjadi kesalahan pada server",
+		MsgNotFound:           "data tidak ditemukan",
+		MsgEndpointNotFound:   "endpoint tidak ditemukan",
+		MsgInvalidID:          "ID menu invalid",
+		MsgInvalidBody:        "request body tidak valid: %v",
+		MsgValidationFailed:   "validasi gagal",
+		MsgFieldRequired:      "%s wajib diisi",
+		MsgMenuNotFound:       "menu tidak ditemukan",
+		MsgDuplicateMenu:      "menu dengan nama '%s' sudah ada (id %d)",
+		MsgVersionMismatch:    "menu sudah diubah oleh pihak lain, ambil ulang data terbaru",
+		MsgIfMatchRequired:    "header If-Match wajib diisi",
+		MsgPatchContentType:   "Content-Type harus %s atau %s",
+		MsgSemanticOff:        "pencarian semantik tidak tersedia",
+		MsgIdemKeyTooLong:     "Idempotency-Key terlalu panjang (maksimal %d karakter)",
+		MsgIdemMismatch:       "Idempotency-Key sudah dipakai untuk request yang berbeda",
+		MsgIdemInProgress:     "request dengan Idempotency-Key yang sama masih diproses",
+		MsgBulkMode:           "mode harus %s atau %s",
+		MsgBulkEmpty:          "operations tidak boleh kosong",
+		MsgBulkTooMany:        "maksimal %d operasi per request",
+		MsgBulkIDRequired:     "id wajib diisi untuk %s",
+		MsgBulkUnknownOp:      "op '%s' tidak dikenal (create, update, delete)",
+		MsgBulkDataRequired:   "data wajib diisi",
+		MsgBulkDataInvalid:    "data tidak valid: %v",
+		MsgBulkRolledBack:     "dibatalkan karena operasi lain gagal",
+		MsgBulkSkipped:        "tidak dijalankan karena operasi lain gagal",
+		MsgDateRange:          "from harus sebelum to",
+		MsgDateFormat:         "format tanggal harus YYYY-MM-DD",
+		MsgThresholdRange:     "threshold harus di antara 0 dan 1",
+		MsgTooManyMenus:       "terlalu banyak menu (%d), batasi dengan parameter category",
+		MsgExportFormat:       "format tidak didukung, gunakan csv, jsonl atau xlsx",
+		MsgImportFormat:       "format tidak didukung, gunakan csv, xlsx atau jsonl",
+		MsgImportUpsertBy:     "upsert_by harus %s atau %s",
+		MsgImportFileRequired: "file import wajib dikirim di field 'file'",
+		MsgImportFileOpen:     "gagal membuka file: %v",
+		MsgImportFile:         "file import tidak valid: %v",
+		MsgImportExternalID:   "external_id wajib diisi untuk upsert_by=external_id",
+		MsgImportDuplicateRow: "duplikat dengan baris %d",
+		MsgImportPrice:        "price '%s' bukan angka",
+		MsgImportCalories:     "calories '%s' bukan bilangan bulat",
+		MsgInvalidColumns:     "kolom export tidak valid: %v",
+		MsgContentType:        "Content-Type harus salah satu dari: %s",
+		MsgVersionRequired:    "argumen version wajib diisi",
+		MsgQueryTooDeep:       "query terlalu dalam (kedalaman %d, maksimal %d)",
+		MsgQueryTooComplex:    "query terlalu kompleks (biaya %d, maksimal %d)",
+		MsgWatchLagged:        "stream tertinggal terlalu jauh, subscribe ulang lalu ambil data terbaru",
+		MsgServerShutdown:     "server sedang dimatikan, coba sambungkan ulang",
+		MsgInvalidEventID:     "last event ID harus berformat <epoch>-<id>",
+		MsgInvalidPathID:      "ID invalid",
+		MsgRelationsEmpty:     "isi minimal satu dari variants, tags atau images",
+
+		MsgWebhookCreated:    "Webhook berhasil dibuat, simpan secret karena tidak ditampilkan lagi",
+		MsgWebhookUpdated:    "Webhook berhasil diupdate",
+		MsgWebhookDeleted:    "Webhook berhasil dihapus",
+		MsgWebhookPing:       "Event ping dijadwalkan",
+		MsgWebhookRedelivery: "Pengiriman dijadwalkan ulang",
+		MsgWebhookNotFound:   "webhook tidak ditemukan",
+		MsgDeliveryNotFound:  "pengiriman webhook tidak ditemukan",
+		MsgWebhookInactive:   "webhook tidak aktif",
+		MsgWebhookURLBlocked: "url webhook tidak boleh mengarah ke jaringan internal (localhost, IP private / link-local)",
+
+		MsgAPIKeyCreated:         "API key berhasil dibuat, simpan key karena tidak ditampilkan lagi",
+		MsgAPIKeyRevoked:         "API key berhasil dicabut",
+		MsgUnauthorized:          "autentikasi diperlukan (Authorization: Bearer <token> atau X-API-Key)",
+		MsgInvalidToken:          "token tidak valid",
+		MsgTokenExpired:          "token sudah kedaluwarsa",
+		MsgInvalidAPIKey:         "API key tidak valid, kedaluwarsa atau sudah dicabut",
+		MsgInvalidCredentials:    "username atau password salah",
+		MsgTokenIssueUnavailable: "server tidak bisa membuat token (private key JWT tidak dikonfigurasi)",
+		MsgAPIKeyNotFound:        "API key tidak ditemukan",
+
+		MsgRoleAssigned:           "role berhasil diberikan",
+		MsgRoleUnassigned:         "role berhasil dicabut",
+		MsgForbidden:              "tidak punya izin: butuh permission %s",
+		MsgUnknownRole:            "role '%s' tidak dikenal, pilih salah satu dari [%s]",
+		MsgInvalidPrincipal:       "principal harus berformat user:<id> atau api_key:<id>",
+		MsgDuplicateRole:          "%s sudah punya role %s",
+		MsgRoleAssignmentNotFound: "role assignment tidak ditemukan",
+		MsgLastAdmin:              "role admin terakhir tidak bisa dicabut",
+
+		MsgSchemaType:     "%s harus bertipe %s",
+		MsgSchemaOneOf:    "%s harus salah satu dari [%s]",
+		MsgSchemaGT:       "%s harus lebih besar dari %s",
+		MsgSchemaGTE:      "%s harus %s atau lebih besar",
+		MsgSchemaLT:       "%s harus lebih kecil dari %s",
+		MsgSchemaLTE:      "%s harus %s atau lebih kecil",
+		MsgSchemaMinLen:   "panjang %s minimal %s karakter",
+		MsgSchemaMaxLen:   "panjang %s maksimal %s karakter",
+		MsgSchemaMinItems: "%s harus berisi minimal %s item",
+		MsgSchemaMaxItems: "%s harus berisi maksimal %s item",
+	},
+	English: {
+		MsgAPIRunning:   "menu API is running",
+		MsgMenuCreated:  "Menu created successfully",
+		MsgMenuUpdated:  "Menu updated successfully",
+		MsgMenuDeleted:  "Menu deleted successfully",
+		MsgRecommended:  "Recommended based on your preferences",
+		MsgSpicy:        "Spicy, as requested",
+		MsgHealthy:      "Healthy with controlled calories",
+		MsgAffordable:   "Affordable price",
+		MsgRecommendSum: "Found %d recommendations for '%s'",
+
+		MsgInternalHidden:     "an internal server error occurred",
+		MsgNotFound:           "resource not found",
+		MsgEndpointNotFound:   "endpoint not found",
+		MsgInvalidID:          "invalid menu ID",
+		MsgInvalidBody:        "invalid request body: %v",
+		MsgValidationFailed:   "validation failed",
+		MsgFieldRequired:      "%s is required",
+		MsgMenuNotFound:       "menu not found",
+		MsgDuplicateMenu:      "a menu named '%s' already exists (id %d)",
+		MsgVersionMismatch:    "menu was modified by someone else, fetch the latest version",
+		MsgIfMatchRequired:    "If-Match header is required",
+		MsgPatchContentType:   "Content-Type must be %s or %s",
+		MsgSemanticOff:        "semantic search is not available",
+		MsgIdemKeyTooLong:     "Idempotency-Key is too long (max %d characters)",
+		MsgIdemMismatch:       "Idempotency-Key was already used for a different request",
+		MsgIdemInProgress:     "a request with the same Idempotency-Key is still being processed",
+		MsgBulkMode:           "mode must be %s or %s",
+		MsgBulkEmpty:          "operations must not be empty",
+		MsgBulkTooMany:        "at most %d operations per request",
+		MsgBulkIDRequired:     "id is required for %s",
+		MsgBulkUnknownOp:      "unknown op '%s' (create, update, delete)",
+		MsgBulkDataRequired:   "data is required",
+		MsgBulkDataInvalid:    "invalid data: %v",
+		MsgBulkRolledBack:     "rolled back because another operation failed",
+		MsgBulkSkipped:        "not executed because another operation failed",
+		MsgDateRange:          "from must be before to",
+		MsgDateFormat:         "date format must be YYYY-MM-DD",
+		MsgThresholdRange:     "threshold must be between 0 and 1",
+		MsgTooManyMenus:       "too many menus (%d), narrow down with the category parameter",
+		MsgExportFormat:       "unsupported format, use csv, jsonl or xlsx",
+		MsgImportFormat:       "unsupported format, use csv, xlsx or jsonl",
+		MsgImportUpsertBy:     "upsert_by must be %s or %s",
+		MsgImportFileRequired: "import file must be sent in the 'file' field",
+		MsgImportFileOpen:     "failed to open file: %v",
+		MsgImportFile:         "invalid import file: %v",
+		MsgImportExternalID:   "external_id is required for upsert_by=external_id",
+		MsgImportDuplicateRow: "duplicate of row %d",
+		MsgImportPrice:        "price '%s' is not a number",
+		MsgImportCalories:     "calories '%s' is not an integer",
+		MsgInvalidColumns:     "invalid export columns: %v",
+		MsgContentType:        "Content-Type must be one of: %s",
+		MsgVersionRequired:    "the version argument is required",
+		MsgQueryTooDeep:       "query is too deep (depth %d, max %d)",
+		MsgQueryTooComplex:    "query is too complex (cost %d, max %d)",
+		MsgWatchLagged:        "stream fell too far behind, resubscribe and fetch the latest data",
+		MsgServerShutdown:     "server is shutting down, reconnect and retry",
+		MsgInvalidEventID:     "last event ID must have the form <epoch>-<id>",
+		MsgInvalidPathID:      "invalid ID",
+		MsgRelationsEmpty:     "provide at least one of variants, tags or images",
+
+		MsgWebhookCreated:    "Webhook created, store the secret as it will not be shown again",
+		MsgWebhookUpdated:    "Webhook updated successfully",
+		MsgWebhookDeleted:    "Webhook deleted successfully",
+		MsgWebhookPing:       "Ping event scheduled",
+		MsgWebhookRedelivery: "Delivery rescheduled",
+		MsgWebhookNotFound:   "webhook not found",
+		MsgDeliveryNotFound:  "webhook delivery not found",
+		MsgWebhookInactive:   "webhook is inactive",
+		MsgWebhookURLBlocked: "webhook url must not point to an internal network (localhost, private / link-local IP)",
+
+		MsgAPIKeyCreated:         "API key created, store the key as it will not be shown again",
+		MsgAPIKeyRevoked:         "API key revoked successfully",
+		MsgUnauthorized:          "authentication required (Authorization: Bearer <token> or X-API-Key)",
+		MsgInvalidToken:          "invalid token",
+		MsgTokenExpired:          "token has expired",
+		MsgInvalidAPIKey:         "API key is invalid, expired or revoked",
+		MsgInvalidCredentials:    "invalid username or password",
+		MsgTokenIssueUnavailable: "server cannot issue tokens (JWT private key not configured)",
+		MsgAPIKeyNotFound:        "API key not found",
+
+		MsgRoleAssigned:           "role assigned successfully",
+		MsgRoleUnassigned:         "role unassigned successfully",
+		MsgForbidden:              "forbidden: permission %s required",
+		MsgUnknownRole:            "unknown role '%s', must be one of [%s]",
+		MsgInvalidPrincipal:       "principal must be formatted as user:<id> or api_key:<id>",
+		MsgDuplicateRole:          "%s already has role %s",
+		MsgRoleAssignmentNotFound: "role assignment not found",
+		MsgLastAdmin:              "the last admin role assignment cannot be removed",
+
+		MsgSchemaType:     "%s must be of type %s",
+		MsgSchemaOneOf:    "%s must be one of [%s]",
+		MsgSchemaGT:       "%s must be greater than %s",
+		MsgSchemaGTE:      "%s must be %s or greater",
+		MsgSchemaLT:       "%s must be less than %s",
+		MsgSchemaLTE:      "%s must be %s or less",
+		MsgSchemaMinLen:   "%s must be at least %s characters long",
+		MsgSchemaMaxLen:   "%s must be at most %s characters long",
+		MsgSchemaMinItems: "%s must contain at least %s items",
+		MsgSchemaMaxItems: "%s must contain at most %s items",
+	},
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"strings"
+
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/services"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	HeaderAPIKey = "X-API-Key"
+	// realm di header WWW-Authenticate response 401
+	authRealm = "menu-catalog"
+)
+
+// baca kredensial request: Authorization: Bearer <JWT atau API key> atau X-API-Key.
+// Principal disimpan di c.Locals & user context; kredensial yang salah langsung 401,
+// request tanpa kredensial diteruskan (RequireAuth yang menolak di route yang butuh login).
+// EventSource & WebSocket browser tidak bisa mengirim header, jadi untuk request
+// text/event-stream & upgrade WebSocket token boleh dikirim lewat query access_token
+func Authenticate(service *services.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		credential := credentials(c)
+		if credential == "" {
+			return c.Next()
+		}
+
+		var principal *auth.Principal
+		var err error
+		if _, ok := auth.APIKeyPrefix(credential); ok {
+			principal, err = service.AuthenticateAPIKey(credential)
+		} else {
+			principal, err = service.AuthenticateToken(credential)
+		}
+		if err != nil {
+			if services.Classify(err).Kind == services.KindUnauthorized {
+				c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="`+authRealm+`", error="invalid_token"`)
+			}
+			return err
+		}
+
+		c.Locals(auth.LocalsKey, principal)
+		c.SetUserContext(auth.WithPrincipal(c.UserContext(), principal))
+		return c.Next()
+	}
+}
+
+// tolak request tanpa principal (401)
+func RequireAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if Principal(c) == nil {
+			c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="`+authRealm+`"`)
+			return services.ErrUnauthorized
+		}
+		return c.Next()
+	}
+}
+
+// tolak request tanpa principal (401) atau tanpa permission perm (403)
+func RequirePermission(perm string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal := Principal(c)
+		if principal == nil {
+			c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="`+authRealm+`"`)
+			return services.ErrUnauthorized
+		}
+		if !principal.Can(perm) {
+			return &services.PermissionError{Permission: perm}
+		}
+		return c.Next()
+	}
+}
+
+// principal request, nil kalau tidak terautentikasi
+func Principal(c *fiber.Ctx) *auth.Principal {
+	principal, _ := c.Locals(auth.LocalsKey).(*auth.Principal)
+	return principal
+}
+
+func credentials(c *fiber.Ctx) string {
+	if header := c.Get(fiber.HeaderAuthorization); header != "" {
+		scheme, token, ok := strings.Cut(header, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		// skema lain (mis. Basic) tidak didukung, dianggap token tidak valid
+		return header
+	}
+	if key := c.Get(HeaderAPIKey); key != "" {
+		return key
+	}
+	if c.Method() == fiber.MethodGet && (strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream") || websocket.IsWebSocketUpgrade(c)) {
+		return c.Query("access_token")
+	}
+	return ""
+}
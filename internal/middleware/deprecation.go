@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// tandai route lama sebagai deprecated: header Deprecation (RFC 9745),
+// Sunset (RFC 8594) dan Link ke path pengganti di versi baru
+func Deprecated(deprecatedAt, sunset time.Time, successorPrefix string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if deprecatedAt.IsZero() {
+			c.Set("Deprecation", "true")
+		} else {
+			c.Set("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+		}
+		if !sunset.IsZero() {
+			c.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		c.Set(fiber.HeaderLink, fmt.Sprintf(`<%s%s>; rel="successor-version"`, successorPrefix, c.Path()))
+		return c.Next()
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed"
+)
+
+// Idempotency-Key untuk POST/PUT/PATCH/DELETE: response pertama disimpan dan
+// dikirim ulang untuk retry dengan key & body yang sama
+func Idempotency(service *services.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" || !isWriteMethod(c.Method()) {
+			return c.Next()
+		}
+		if len(key) > services.MaxIdempotencyKeyLength {
+			return services.Invalid(services.CodeInvalidRequest, i18n.MsgIdemKeyTooLong, services.MaxIdempotencyKeyLength)
+		}
+
+		// key disalin, nilai header fiber hanya valid selama request.
+		// Key dipisah per principal supaya response tidak bisa di-replay pemanggil lain
+		key = string([]byte(key))
+		if principal := Principal(c); principal != nil {
+			sum := sha256.Sum256([]byte(key))
+			key = principal.Key() + ":" + hex.EncodeToString(sum[:])
+		}
+		record, err := service.Begin(key, c.Method(), c.OriginalURL(), c.Body())
+		if err != nil {
+			return err
+		}
+
+		// replay response pertama
+		if record != nil {
+			c.Set(HeaderReplayed, "true")
+			if record.ContentType != "" {
+				c.Set(fiber.HeaderContentType, record.ContentType)
+			}
+			if record.ETag != "" {
+				c.Set(fiber.HeaderETag, record.ETag)
+			}
+			return c.Status(record.StatusCode).Send(record.Body)
+		}
+
+		// panic di handler: key dilepas dulu sebelum panic diteruskan ke recover,
+		// kalau tidak key terkunci sampai lease habis
+		defer func() {
+			if r := recover(); r != nil {
+				service.Release(key)
+				panic(r)
+			}
+		}()
+
+		// error handler dipanggil di sini supaya response error 4xx ikut disimpan
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				service.Release(key)
+				return err
+			}
+		}
+
+		// server error, 401 & 403 tidak disimpan supaya client bisa retry setelah
+		// login / diberi role, response no-store (access token, API key baru)
+		// tidak boleh tersimpan di db
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError || status == fiber.StatusUnauthorized || status == fiber.StatusForbidden ||
+			strings.Contains(string(c.Response().Header.Peek(fiber.HeaderCacheControl)), "no-store") {
+			service.Release(key)
+			return nil
+		}
+		service.Complete(key, status,
+			string(c.Response().Header.ContentType()),
+			string(c.Response().Header.Peek(fiber.HeaderETag)),
+			append([]byte(nil), c.Response().Body()...),
+		)
+		return nil
+	}
+}
+
+func isWriteMethod(method string) bool {
+	switch method {
+	case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"GDGOC-API/internal/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// pilih bahasa response dari Accept-Language (fallback DEFAULT_LANGUAGE),
+// disimpan di c.Locals untuk handler & error handler
+func Language() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lang := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage), i18n.Default())
+		c.Locals(i18n.LocalsKey, lang)
+		c.Set(fiber.HeaderContentLanguage, lang)
+		c.Vary(fiber.HeaderAcceptLanguage)
+		return c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// sumber query yang dicatat
+const (
+	SearchSourceSearch         = "search"
+	SearchSourceSemantic       = "semantic"
+	SearchSourceRecommendation = "recommendation"
+)
+
+// log satu kali pencarian
+type SearchLog struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Source          string    `gorm:"type:varchar(30);not null;index" json:"source"`
+	Query           string    `gorm:"type:text;not null" json:"query"`
+	NormalizedQuery string    `gorm:"type:text;not null;index" json:"normalized_query"`
+	ResultCount     int       `gorm:"not null" json:"result_count"`
+	LatencyMs       int64     `gorm:"not null" json:"latency_ms"`
+	CreatedAt       time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+func (SearchLog) TableName() string {
+	return "search_logs"
+}
+
+type QueryStat struct {
+	Query        string  `json:"query"`
+	Count        int64   `json:"count"`
+	AvgResults   float64 `json:"avg_results"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+type DailySearchReport struct {
+	Date              string      `json:"date"`
+	TotalSearches     int64       `json:"total_searches"`
+	ZeroResultCount   int64       `json:"zero_result_count"`
+	TopQueries        []QueryStat `json:"top_queries"`
+	ZeroResultQueries []QueryStat `json:"zero_result_queries"`
+}
+
+type SearchReportResponse struct {
+	From   string              `json:"from"`
+	To     string              `json:"to"`
+	Source string              `json:"source,omitempty"`
+	Days   []DailySearchReport `json:"days"`
+}
@@ -0,0 +1,112 @@
+package models
+
+import "time"
+
+// user lokal yang bisa minta token lewat POST /auth/token
+type User struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Username     string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"username"`
+	PasswordHash string    `gorm:"type:varchar(100);not null" json:"-"`
+	Active       bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (User) TableName() string {
+	return "users"
+}
+
+// API key untuk client server-to-server, hanya hash SHA-256 yang disimpan.
+// Prefix (bagian depan key) dipakai untuk mencari key & ditampilkan di daftar
+type APIKey struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	Prefix     string     `gorm:"type:varchar(16);not null;uniqueIndex" json:"prefix"`
+	Hash       string     `gorm:"type:varchar(64);not null" json:"-"`
+	CreatedBy  string     `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// role bawaan (auth.Roles) yang diberikan ke user / API key
+type RoleAssignment struct {
+	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
+	// key principal, mis. user:3 atau api_key:7
+	Principal string    `gorm:"type:varchar(120);not null;uniqueIndex:idx_role_assignments_principal_role" json:"principal"`
+	Role      string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_role_assignments_principal_role" json:"role"`
+	CreatedBy string    `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (RoleAssignment) TableName() string {
+	return "role_assignments"
+}
+
+type TokenRequest struct {
+	Username string `json:"username" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// response token (mengikuti bentuk response token OAuth 2.0)
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	// 0 / kosong = tidak kedaluwarsa
+	ExpiresInDays int `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=3650"`
+}
+
+// response create: satu-satunya tempat key lengkap dikirim ke client
+type APIKeyCreatedResponse struct {
+	Message string `json:"message,omitempty"`
+	Data    APIKey `json:"data"`
+	Key     string `json:"key"`
+}
+
+type APIKeyListResponse struct {
+	Data []APIKey `json:"data"`
+}
+
+// principal request saat ini (GET /auth/me)
+type PrincipalResponse struct {
+	Type        string   `json:"type"`
+	ID          uint     `json:"id,omitempty"`
+	Subject     string   `json:"subject"`
+	Name        string   `json:"name"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleListResponse struct {
+	Data []RoleResponse `json:"data"`
+}
+
+type CreateRoleAssignmentRequest struct {
+	Principal string `json:"principal" validate:"required,max=120"`
+	Role      string `json:"role" validate:"required,max=50"`
+}
+
+type RoleAssignmentResponse struct {
+	Message string         `json:"message,omitempty"`
+	Data    RoleAssignment `json:"data"`
+}
+
+type RoleAssignmentListResponse struct {
+	Data []RoleAssignment `json:"data"`
+}
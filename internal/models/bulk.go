@@ -0,0 +1,54 @@
+package models
+
+import "encoding/json"
+
+const (
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+
+	BulkOpCreate = "create"
+	BulkOpUpdate = "update"
+	BulkOpDelete = "delete"
+)
+
+// status hasil per operasi bulk
+const (
+	BulkStatusCreated    = "created"
+	BulkStatusUpdated    = "updated"
+	BulkStatusDeleted    = "deleted"
+	BulkStatusFailed     = "failed"
+	BulkStatusRolledBack = "rolled_back"
+	BulkStatusSkipped    = "skipped"
+)
+
+// satu operasi dalam request bulk, Data di-decode sesuai Op
+type BulkOperation struct {
+	Op      string          `json:"op"`
+	ID      uint            `json:"id,omitempty"`
+	Version *uint           `json:"version,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type BulkRequest struct {
+	Mode       string          `json:"mode"`
+	Operations []BulkOperation `json:"operations"`
+}
+
+type BulkItemResult struct {
+	Index  int         `json:"index"`
+	Op     string      `json:"op"`
+	ID     uint        `json:"id,omitempty"`
+	Status string      `json:"status"`
+	Code   int         `json:"code"`
+	Error     interface{}  `json:"error,omitempty"`
+	ErrorCode string       `json:"error_code,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	Data      *Menu        `json:"data,omitempty"`
+}
+
+type BulkResponse struct {
+	Mode      string           `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
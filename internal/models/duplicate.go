@@ -0,0 +1,35 @@
+package models
+
+import "strings"
+
+// cakupan keunikan nama menu (MENU_UNIQUE_NAME)
+const (
+	UniqueNameCategory = "category"
+	UniqueNameGlobal   = "global"
+	UniqueNameOff      = "off"
+)
+
+// nama untuk cek duplikat: huruf kecil & spasi dirapikan
+func NormalizeMenuName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+type DuplicateMenuRef struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// pasangan menu dengan nama mirip
+type NearDuplicatePair struct {
+	A          DuplicateMenuRef `json:"a"`
+	B          DuplicateMenuRef `json:"b"`
+	Similarity float64          `json:"similarity"`
+}
+
+type NearDuplicateReport struct {
+	Threshold     float64             `json:"threshold"`
+	CrossCategory bool                `json:"cross_category"`
+	Scanned       int                 `json:"scanned"`
+	Pairs         []NearDuplicatePair `json:"pairs"`
+}
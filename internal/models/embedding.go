@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// vektor embedding per menu
+type MenuEmbedding struct {
+	MenuID    uint            `gorm:"primaryKey;autoIncrement:false" json:"menu_id"`
+	Provider  string          `gorm:"type:varchar(100);not null" json:"provider"`
+	Vector    pq.Float32Array `gorm:"type:real[];not null" json:"-"`
+	UpdatedAt time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (MenuEmbedding) TableName() string {
+	return "menu_embeddings"
+}
+
+type SemanticSearchResult struct {
+	Menu         Menu    `json:"menu"`
+	Score        float64 `json:"score"`
+	VectorScore  float64 `json:"vector_score"`
+	KeywordScore float64 `json:"keyword_score"`
+}
+
+type SemanticSearchResponse struct {
+	Query string                 `json:"query"`
+	Mode  string                 `json:"mode"`
+	Data  []SemanticSearchResult `json:"data"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// jenis pesan reset di feed perubahan katalog
+const CatalogFeedResetType = "reset"
+
+// dikirim kalau event sejak last event ID sudah tidak ada di log (atau ID tidak dikenal):
+// client ambil ulang data (GET /menu) lalu lanjut dari LastEventID ("<epoch>-<id>")
+type CatalogFeedReset struct {
+	Type        string `json:"type"`
+	LastEventID string `json:"last_event_id"`
+}
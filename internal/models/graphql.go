@@ -0,0 +1,27 @@
+package models
+
+// body POST /graphql
+type GraphQLRequest struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// bentuk response GraphQL (data & errors), dipakai untuk dokumentasi OpenAPI
+type GraphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []GraphQLError         `json:"errors,omitempty"`
+}
+
+// error GraphQL, extensions berisi code, status & fields seperti problem+json
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLLocation      `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
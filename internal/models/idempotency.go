@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// response pertama untuk satu Idempotency-Key, StatusCode 0 = request masih diproses.
+// LockedUntil = batas lease request yang sedang diproses, lewat dari itu key boleh diklaim ulang
+type IdempotencyRecord struct {
+	Key         string    `gorm:"primaryKey;type:varchar(255)" json:"key"`
+	Method      string    `gorm:"type:varchar(10);not null" json:"method"`
+	Path        string    `gorm:"type:text;not null" json:"path"`
+	Fingerprint string    `gorm:"type:char(64);not null" json:"fingerprint"`
+	StatusCode  int       `gorm:"not null;default:0" json:"status_code"`
+	ContentType string    `gorm:"type:varchar(255)" json:"content_type"`
+	ETag        string    `gorm:"type:varchar(255)" json:"etag"`
+	Body        []byte    `gorm:"type:bytea" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	LockedUntil time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"locked_until"`
+	ExpiresAt   time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_keys"
+}
@@ -0,0 +1,39 @@
+package models
+
+const (
+	ImportUpsertByName       = "name"
+	ImportUpsertByExternalID = "external_id"
+)
+
+// aksi per baris import
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+	ImportActionFailed = "failed"
+)
+
+type ImportOptions struct {
+	Format   string
+	DryRun   bool
+	UpsertBy string
+}
+
+type ImportRowResult struct {
+	Line   int      `json:"line"`
+	Name   string   `json:"name,omitempty"`
+	Action string   `json:"action"`
+	ID     uint     `json:"id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun   bool              `json:"dry_run"`
+	UpsertBy string            `json:"upsert_by"`
+	Total    int               `json:"total"`
+	Created  int               `json:"created"`
+	Updated  int               `json:"updated"`
+	Skipped  int               `json:"skipped"`
+	Failed   int               `json:"failed"`
+	Rows     []ImportRowResult `json:"rows"`
+}
@@ -7,14 +7,21 @@ import(
 
 type Menu struct {
 	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ExternalID  *string        `gorm:"type:varchar(100);uniqueIndex" json:"external_id,omitempty"`
 	Name        string         `gorm:"type:varchar(255);not null" json:"name" validate:"required,min=3,max=255"`
+	NormalizedName string      `gorm:"type:varchar(255);not null;default:''" json:"-"`
 	Category    string         `gorm:"type:varchar(100);not null;index" json:"category" validate:"required,oneof=foods drinks desserts snacks"`
 	Calories    *int           `gorm:"type:integer" json:"calories" validate:"omitempty,gte=0"`
 	Price       float64        `gorm:"type:decimal(10,2);not null" json:"price" validate:"required,gt=0"`
 	Ingredients pq.StringArray `gorm:"type:text[]" json:"ingredients" validate:"required,min=1"`
 	Description string         `gorm:"type:text" json:"description" validate:"omitempty,max=1000"`
+	ViewCount   int64          `gorm:"not null;default:0" json:"view_count"`
+	Version     uint           `gorm:"not null;default:1" json:"version"`
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	Variants    []MenuVariant  `gorm:"foreignKey:MenuID;constraint:OnDelete:CASCADE" json:"variants,omitempty"`
+	Tags        []Tag          `gorm:"many2many:menu_tags;constraint:OnDelete:CASCADE" json:"tags,omitempty"`
+	Images      []MenuImage    `gorm:"foreignKey:MenuID;constraint:OnDelete:CASCADE" json:"images,omitempty"`
 }
 
 func (Menu) TableName() string{
@@ -23,6 +30,7 @@ func (Menu) TableName() string{
 
 
 type CreateMenuRequest struct {
+	ExternalID  *string  `json:"external_id,omitempty" validate:"omitempty,max=100"`
 	Name        string   `json:"name" validate:"required,min=3,max=255"`
 	Category    string   `json:"category" validate:"required,oneof=foods drinks desserts snacks"`
 	Calories    *int     `json:"calories" validate:"omitempty,gte=0"`
@@ -46,31 +54,63 @@ type MenuFilters struct {
 	MinPrice    float64   `query:"min_price"`
 	MaxPrice       float64  `query:"max_price"`
 	MaxCalories int `query:"max_cal"`
+	Filter	string	`query:"filter"`
 	Page int   `query:"page"`
 	PerPage	int	`query:"per_page"`
 	Sort	string	`query:"sort"`
+	Cursor	string	`query:"cursor"`
+	CursorMode	bool	`query:"-"`
+	WithTotal	bool	`query:"with_total"`
+	Fields	string	`query:"fields"`
+	Include	string	`query:"include"`
 }
 
+// pilihan kolom & relasi dari filter
+func (f MenuFilters) View() MenuView{
+	return MenuView{Fields: f.Fields, Include: f.Include}
+}
+
+// parameter paging & sorting dari filter
+func (f MenuFilters) PageParams() PageParams{
+	return PageParams{
+		Page: f.Page,
+		PerPage: f.PerPage,
+		Sort: f.Sort,
+		Cursor: f.Cursor,
+		CursorMode: f.CursorMode,
+		WithTotal: f.WithTotal,
+	}
+}
+
+// paging offset (page) atau cursor; CursorMode aktif kalau param cursor dikirim
+type PageParams struct{
+	Page	int
+	PerPage	int
+	Sort	string
+	Cursor	string
+	CursorMode	bool
+	WithTotal	bool
+}
+
+// Total & TotalPages hanya terisi kalau with_total aktif
 type PaginationMeta struct{
-	Total	int64	`json:"total"`
-	Page	int		`json:"page"`
+	Total	*int64	`json:"total,omitempty"`
+	Page	int		`json:"page,omitempty"`
 	PerPage	int		`json:"per_page"`
-	TotalPages	int	`json:"total_pages"`
+	TotalPages	*int	`json:"total_pages,omitempty"`
+	NextCursor	string	`json:"next_cursor,omitempty"`
+	PrevCursor	string	`json:"prev_cursor,omitempty"`
 }
 
+// Data berisi []Menu, atau []map kalau fields= dipakai
 type MenuListResponse struct{
-	Data	[]Menu	`json:"data"`
+	Data	interface{}	`json:"data" openapi:"[]Menu"`
 	Pagination	*PaginationMeta	`json:"pagination,omitempty"`
 }
 
 type MenuResponse struct{
 	Message	string	`json:"message,omitempty"`
-	Data	Menu	`json:"data"`
-}
-
-type ErrorResponse struct{
-	Message	string	`json:"message"`
-	Errors	interface{}	`json:"errors,omitempty"`
+	Data	interface{}	`json:"data" openapi:"Menu"`
 }
 
 type MessageResponse struct{
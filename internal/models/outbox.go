@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// event perubahan menu yang ditulis dalam transaksi yang sama dengan perubahan menunya,
+// lalu dikirim relay ke sink (at-least-once, urut per menu). ID dipakai sebagai id event
+// di sink yang tahan restart (webhook, NATS, log) supaya penerima bisa deduplikasi
+type OutboxEvent struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement"`
+	AggregateID uint   `gorm:"not null;index"`
+	EventType   string `gorm:"type:varchar(50);not null"`
+	Version     uint   `gorm:"not null"`
+	// snapshot menu saat event terjadi (JSON)
+	Payload       string    `gorm:"type:text;not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null;index"`
+	LastError     string    `gorm:"type:text"`
+	// sink yang sudah menerima event ini, tidak dikirim lagi saat event dicoba ulang
+	SinksDone   pq.StringArray `gorm:"type:text[]"`
+	PublishedAt *time.Time     `gorm:"index"`
+	// event gagal terus sampai jatah percobaan habis (atau payload rusak), tidak dikirim
+	// lagi & tidak menahan event berikutnya untuk menu yang sama
+	ParkedAt  *time.Time `gorm:"index"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,25 @@
+package models
+
+// error per field hasil validasi, In = lokasi (query, path, header, body)
+// untuk error dari validasi kontrak OpenAPI
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in,omitempty"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// response error RFC 7807 (application/problem+json), Code stabil untuk client
+type Problem struct {
+	Type       string       `json:"type"`
+	Title      string       `json:"title"`
+	Status     int          `json:"status"`
+	Detail     string       `json:"detail,omitempty"`
+	Instance   string       `json:"instance,omitempty"`
+	Code       string       `json:"code"`
+	Errors     []FieldError `json:"errors,omitempty"`
+	ExistingID uint         `json:"existing_id,omitempty"`
+	// permission yang dibutuhkan (403 forbidden)
+	MissingPermission string `json:"missing_permission,omitempty"`
+}
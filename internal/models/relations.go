@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// varian menu (ukuran/porsi) dengan harga sendiri
+type MenuVariant struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	MenuID    uint      `gorm:"not null;index" json:"menu_id"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	Price     float64   `gorm:"type:decimal(10,2);not null" json:"price"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (MenuVariant) TableName() string {
+	return "menu_variants"
+}
+
+// tag menu (pedas, halal, best seller, ...), relasi many-to-many lewat menu_tags
+type Tag struct {
+	ID   uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name string `gorm:"type:varchar(50);not null;uniqueIndex" json:"name"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// gambar menu, diurutkan berdasarkan Position
+type MenuImage struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	MenuID    uint      `gorm:"not null;index" json:"menu_id"`
+	URL       string    `gorm:"type:text;not null" json:"url"`
+	Alt       string    `gorm:"type:varchar(255)" json:"alt,omitempty"`
+	Position  int       `gorm:"not null;default:0" json:"position"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (MenuImage) TableName() string {
+	return "menu_images"
+}
+
+// pilihan kolom (fields=) & relasi (include=) untuk endpoint baca
+type MenuView struct {
+	Fields  string
+	Include string
+}
+
+// varian baru untuk PUT /menu/:id/relations
+type MenuVariantInput struct {
+	Name  string  `json:"name" validate:"required,max=100"`
+	Price float64 `json:"price" validate:"required,gt=0"`
+}
+
+// gambar baru untuk PUT /menu/:id/relations
+type MenuImageInput struct {
+	URL      string `json:"url" validate:"required,url,max=2048"`
+	Alt      string `json:"alt,omitempty" validate:"omitempty,max=255"`
+	Position int    `json:"position" validate:"gte=0"`
+}
+
+// ganti varian, tag & gambar menu. Field yang tidak dikirim (null) tidak diubah,
+// array kosong menghapus semua isi relasi itu
+type UpdateMenuRelationsRequest struct {
+	Variants *[]MenuVariantInput `json:"variants,omitempty" validate:"omitempty,max=20,dive"`
+	Tags     *[]string           `json:"tags,omitempty" validate:"omitempty,max=20,dive,required,max=50"`
+	Images   *[]MenuImageInput   `json:"images,omitempty" validate:"omitempty,max=20,dive"`
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// event test yang dikirim lewat POST /webhooks/:id/ping
+const WebhookEventPing = "webhook.ping"
+
+// status pengiriman webhook, dead = retry habis (dead-letter), bisa dikirim ulang manual
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead"
+)
+
+// langganan webhook: event di Events dikirim ke URL dengan signature HMAC dari Secret
+type Webhook struct {
+	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL         string         `gorm:"type:text;not null" json:"url"`
+	Secret      string         `gorm:"type:varchar(100);not null" json:"-"`
+	Events      pq.StringArray `gorm:"type:text[];not null" json:"events"`
+	Description string         `gorm:"type:varchar(255)" json:"description,omitempty"`
+	Active      bool           `gorm:"not null;default:true" json:"active"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Deliveries []WebhookDelivery `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// satu event untuk satu webhook, Payload disimpan supaya retry & redelivery mengirim body yang sama
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	WebhookID      uint       `gorm:"not null;index" json:"webhook_id"`
+	EventID        uint64     `gorm:"not null" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  *time.Time `gorm:"index" json:"next_attempt_at,omitempty"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Logs []WebhookDeliveryLog `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE" json:"logs,omitempty"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// log satu percobaan kirim
+type WebhookDeliveryLog struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	DeliveryID   uint      `gorm:"not null;index" json:"delivery_id"`
+	Attempt      int       `gorm:"not null" json:"attempt"`
+	StatusCode   *int      `json:"status_code,omitempty"`
+	Error        string    `gorm:"type:text" json:"error,omitempty"`
+	ResponseBody string    `gorm:"type:text" json:"response_body,omitempty"`
+	DurationMs   int64     `gorm:"not null" json:"duration_ms"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (WebhookDeliveryLog) TableName() string {
+	return "webhook_delivery_logs"
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=2000"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=menu.created menu.updated menu.deleted"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+	// kosong = dibuatkan server, hanya ditampilkan sekali di response create
+	Secret string `json:"secret,omitempty" validate:"omitempty,min=16,max=100"`
+}
+
+type UpdateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=2000"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=menu.created menu.updated menu.deleted"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// response create: satu-satunya tempat secret dikirim ke client
+type WebhookCreatedResponse struct {
+	Message string  `json:"message,omitempty"`
+	Data    Webhook `json:"data"`
+	Secret  string  `json:"secret"`
+}
+
+type WebhookResponse struct {
+	Message string  `json:"message,omitempty"`
+	Data    Webhook `json:"data"`
+}
+
+type WebhookListResponse struct {
+	Data []Webhook `json:"data"`
+}
+
+type WebhookDeliveryResponse struct {
+	Message string          `json:"message,omitempty"`
+	Data    WebhookDelivery `json:"data"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Data       []WebhookDelivery `json:"data"`
+	Pagination *PaginationMeta   `json:"pagination,omitempty"`
+}
+
+// filter daftar pengiriman, Status dead = daftar dead-letter
+type WebhookDeliveryFilters struct {
+	WebhookID uint
+	Status    string
+	Page      int
+	PerPage   int
+}
@@ -0,0 +1,341 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// pembuat schema dari struct Go lewat reflection. Struct bernama disimpan di
+// components dan direferensikan dengan $ref; tag validate diubah jadi constraint
+type Generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schema untuk nilai v (boleh nil = schema bebas)
+func (g *Generator) Schema(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return g.schemaOf(reflect.TypeOf(v))
+}
+
+// daftarkan struct ke components tanpa dipakai langsung (target tag openapi)
+func (g *Generator) Register(values ...interface{}) {
+	for _, v := range values {
+		g.Schema(v)
+	}
+}
+
+func (g *Generator) Components() map[string]*Schema {
+	return g.schemas
+}
+
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := g.schemaOf(t.Elem())
+		return nullable(schema)
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: float(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.ref(t)
+	}
+	// interface{} dan tipe lain: schema bebas
+	return &Schema{}
+}
+
+// struct bernama: simpan di components sekali, selanjutnya pakai $ref
+func (g *Generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := g.schemas[name]; taken {
+			// nama sama dari package lain, diberi prefix nama package
+			name = pkgName(t) + name
+		}
+		g.names[t] = name
+		g.schemas[name] = &Schema{} // placeholder untuk struct rekursif
+		*g.schemas[name] = *g.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, t)
+	return schema
+}
+
+func (g *Generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		// embedded struct tanpa nama json: field-nya digabung
+		if field.Anonymous && name == field.Name && field.Type.Kind() == reflect.Struct {
+			g.addFields(schema, field.Type)
+			continue
+		}
+
+		var prop *Schema
+		if override := field.Tag.Get("openapi"); override != "" {
+			prop = g.override(override)
+		} else {
+			prop = g.schemaOf(field.Type)
+		}
+
+		required := applyValidate(prop, field.Tag.Get("validate"))
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+}
+
+// tag openapi:"Menu" / openapi:"[]Menu" untuk field interface{}, nama = schema di components
+func (g *Generator) override(tag string) *Schema {
+	var options []*Schema
+	for _, name := range strings.Split(tag, "|") {
+		name = strings.TrimSpace(name)
+		if strings.HasPrefix(name, "[]") {
+			options = append(options, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/" + strings.TrimPrefix(name, "[]")}})
+			continue
+		}
+		options = append(options, &Schema{Ref: "#/components/schemas/" + name})
+	}
+	if len(options) == 1 {
+		return options[0]
+	}
+	return &Schema{OneOf: options}
+}
+
+// nama schema yang belum terdaftar di components (hasil tag openapi yang salah ketik)
+func (g *Generator) MissingRefs() []string {
+	var missing []string
+	seen := make(map[string]bool)
+	var walk func(s *Schema)
+	walk = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+			if _, ok := g.schemas[name]; !ok && !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		}
+		walk(s.Items)
+		walk(s.AdditionalProperties)
+		for _, p := range s.Properties {
+			walk(p)
+		}
+		for _, o := range s.OneOf {
+			walk(o)
+		}
+	}
+	for _, s := range g.schemas {
+		walk(s)
+	}
+	return missing
+}
+
+// nama field di JSON, skip = field tidak ikut diserialisasi
+func jsonName(field reflect.StructField) (name string, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name = strings.SplitN(tag, ",", 2)[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name, false
+}
+
+// ubah aturan validator jadi constraint JSON Schema, return true kalau field wajib.
+// Aturan setelah "dive" berlaku untuk item array
+func applyValidate(schema *Schema, tag string) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	required := false
+	target := schema
+	for _, rule := range strings.Split(tag, ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		if key == "dive" {
+			if items := target.Items; items != nil {
+				target = items
+			}
+			continue
+		}
+		if key == "required" && target == schema {
+			required = true
+			continue
+		}
+		applyRule(target, key, param)
+	}
+	return required
+}
+
+func applyRule(schema *Schema, key, param string) {
+	typ, _ := schema.Type.(string)
+	if types, ok := schema.Type.([]string); ok && len(types) > 0 {
+		typ = types[0]
+	}
+	n, err := strconv.ParseFloat(param, 64)
+	hasNumber := err == nil
+
+	switch key {
+	case "min", "max", "len":
+		if !hasNumber {
+			return
+		}
+		size := int(n)
+		switch typ {
+		case "string":
+			if key != "max" {
+				schema.MinLength = &size
+			}
+			if key != "min" {
+				schema.MaxLength = &size
+			}
+		case "array":
+			if key != "max" {
+				schema.MinItems = &size
+			}
+			if key != "min" {
+				schema.MaxItems = &size
+			}
+		default:
+			if key != "max" {
+				schema.Minimum = &n
+			}
+			if key != "min" {
+				schema.Maximum = &n
+			}
+		}
+	case "gt":
+		if hasNumber {
+			schema.ExclusiveMinimum = &n
+		}
+	case "gte":
+		if hasNumber {
+			schema.Minimum = &n
+		}
+	case "lt":
+		if hasNumber {
+			schema.ExclusiveMaximum = &n
+		}
+	case "lte":
+		if hasNumber {
+			schema.Maximum = &n
+		}
+	case "oneof":
+		for _, value := range strings.Fields(param) {
+			if typ == "integer" || typ == "number" {
+				if v, err := strconv.ParseFloat(value, 64); err == nil {
+					schema.Enum = append(schema.Enum, v)
+					continue
+				}
+			}
+			schema.Enum = append(schema.Enum, value)
+		}
+	case "email", "url", "uri", "uuid", "hostname":
+		schema.Format = map[string]string{"url": "uri"}[key]
+		if schema.Format == "" {
+			schema.Format = key
+		}
+	}
+}
+
+// pointer = boleh null
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{OneOf: []*Schema{schema, {Type: "null"}}}
+	}
+	if typ, ok := schema.Type.(string); ok {
+		schema.Type = []string{typ, "null"}
+	}
+	return schema
+}
+
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	name := path[strings.LastIndex(path, "/")+1:]
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func float(v float64) *float64 {
+	return &v
+}
+
+// path fiber /menu/:id jadi /menu/{id}, beserta nama parameter path
+func ConvertPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			name := strings.TrimSuffix(strings.TrimPrefix(segment, ":"), "?")
+			params = append(params, name)
+			segments[i] = fmt.Sprintf("{%s}", name)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
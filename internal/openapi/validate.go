@@ -0,0 +1,309 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lokasi nilai yang divalidasi
+const (
+	InQuery  = "query"
+	InPath   = "path"
+	InHeader = "header"
+	InBody   = "body"
+)
+
+// satu nilai yang tidak sesuai schema. Rule memakai nama aturan validator
+// (required, oneof, gt, min, ...) supaya sama dengan error validasi struct,
+// Type = tipe JSON nilai yang dicek (untuk memilih pesan min/max)
+type Violation struct {
+	In    string
+	Field string
+	Rule  string
+	Param string
+	Type  string
+}
+
+// hasil validasi request terhadap kontrak OpenAPI
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		part := fmt.Sprintf("%s %s: %s", v.In, v.Field, v.Rule)
+		if v.Param != "" {
+			part += "=" + v.Param
+		}
+		parts = append(parts, part)
+	}
+	return "request tidak sesuai schema: " + strings.Join(parts, "; ")
+}
+
+// schema dari $ref components, schema lain dikembalikan apa adanya
+func (d *Document) Resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// parameter dari $ref components
+func (d *Document) Parameter(param *Parameter) *Parameter {
+	if param != nil && param.Ref != "" {
+		return d.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+	}
+	return param
+}
+
+// ubah nilai parameter (query/path/header) ke tipe di schema lalu validasi.
+// Format angka/boolean yang salah dilaporkan sebagai rule "type"
+func (d *Document) ValidateParam(param *Parameter, raw string) []Violation {
+	schema := d.Resolve(param.Schema)
+	if schema == nil {
+		return nil
+	}
+
+	var value interface{} = raw
+	switch primaryType(schema) {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return []Violation{{In: param.In, Field: param.Name, Rule: "type", Param: "integer"}}
+		}
+		value = float64(n)
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return []Violation{{In: param.In, Field: param.Name, Rule: "type", Param: "number"}}
+		}
+		value = n
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []Violation{{In: param.In, Field: param.Name, Rule: "type", Param: "boolean"}}
+		}
+		value = b
+	}
+	return d.Validate(schema, value, param.In, param.Name)
+}
+
+// validasi nilai hasil json.Unmarshal (map, slice, float64, string, bool, nil)
+// terhadap schema. format dianggap anotasi (perilaku default JSON Schema 2020-12)
+func (d *Document) Validate(schema *Schema, value interface{}, in, field string) []Violation {
+	schema = d.Resolve(schema)
+	if schema == nil {
+		return nil
+	}
+	violation := func(rule, param string) []Violation {
+		return []Violation{{In: in, Field: field, Rule: rule, Param: param, Type: jsonType(value)}}
+	}
+
+	if len(schema.OneOf) > 0 {
+		return d.validateOneOf(schema, value, in, field)
+	}
+
+	if types := schemaTypes(schema); len(types) > 0 && !matchesType(types, value) {
+		return violation("type", strings.Join(types, "|"))
+	}
+	if value == nil {
+		return nil
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		options := make([]string, 0, len(schema.Enum))
+		for _, e := range schema.Enum {
+			options = append(options, fmt.Sprint(e))
+		}
+		return violation("oneof", strings.Join(options, " "))
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return d.validateNumber(schema, v, violation)
+	case string:
+		length := len([]rune(v))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			return violation("min", strconv.Itoa(*schema.MinLength))
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return violation("max", strconv.Itoa(*schema.MaxLength))
+		}
+	case []interface{}:
+		if schema.MinItems != nil && len(v) < *schema.MinItems {
+			return violation("min", strconv.Itoa(*schema.MinItems))
+		}
+		if schema.MaxItems != nil && len(v) > *schema.MaxItems {
+			return violation("max", strconv.Itoa(*schema.MaxItems))
+		}
+		var violations []Violation
+		for i, item := range v {
+			violations = append(violations, d.Validate(schema.Items, item, in, fmt.Sprintf("%s[%d]", field, i))...)
+		}
+		return violations
+	case map[string]interface{}:
+		return d.validateObject(schema, v, in, field)
+	}
+	return nil
+}
+
+func (d *Document) validateNumber(schema *Schema, n float64, violation func(rule, param string) []Violation) []Violation {
+	switch {
+	case schema.Minimum != nil && n < *schema.Minimum:
+		return violation("gte", formatNumber(*schema.Minimum))
+	case schema.ExclusiveMinimum != nil && n <= *schema.ExclusiveMinimum:
+		return violation("gt", formatNumber(*schema.ExclusiveMinimum))
+	case schema.Maximum != nil && n > *schema.Maximum:
+		return violation("lte", formatNumber(*schema.Maximum))
+	case schema.ExclusiveMaximum != nil && n >= *schema.ExclusiveMaximum:
+		return violation("lt", formatNumber(*schema.ExclusiveMaximum))
+	}
+	return nil
+}
+
+// field wajib dulu, lalu property diurutkan supaya urutan error stabil
+func (d *Document) validateObject(schema *Schema, object map[string]interface{}, in, field string) []Violation {
+	var violations []Violation
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			violations = append(violations, Violation{In: in, Field: join(field, name), Rule: "required"})
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, ok := schema.Properties[name]
+		if !ok {
+			prop = schema.AdditionalProperties
+		}
+		if prop != nil {
+			violations = append(violations, d.Validate(prop, object[name], in, join(field, name))...)
+		}
+	}
+	return violations
+}
+
+// oneOf: null cocok dengan opsi {type: null}, kalau hanya ada satu opsi lain
+// (atau satu opsi dengan tipe yang sama) error dari opsi itu yang dilaporkan
+func (d *Document) validateOneOf(schema *Schema, value interface{}, in, field string) []Violation {
+	var candidates []*Schema
+	matched := 0
+	for _, option := range schema.OneOf {
+		if types := schemaTypes(d.Resolve(option)); len(types) == 1 && types[0] == "null" {
+			if value == nil {
+				return nil
+			}
+			continue
+		}
+		candidates = append(candidates, option)
+		if len(d.Validate(option, value, in, field)) == 0 {
+			matched++
+		}
+	}
+
+	if matched == 1 {
+		return nil
+	}
+
+	// hanya satu opsi yang tipenya cocok: laporkan error dari opsi itu
+	var types []string
+	var sameType []*Schema
+	for _, option := range candidates {
+		optionTypes := schemaTypes(d.Resolve(option))
+		types = append(types, optionTypes...)
+		if len(optionTypes) == 0 || matchesType(optionTypes, value) {
+			sameType = append(sameType, option)
+		}
+	}
+	if len(candidates) == 1 || len(sameType) == 1 {
+		if len(sameType) == 1 {
+			candidates = sameType
+		}
+		return d.Validate(candidates[0], value, in, field)
+	}
+	return []Violation{{In: in, Field: field, Rule: "type", Param: strings.Join(types, "|"), Type: jsonType(value)}}
+}
+
+func schemaTypes(schema *Schema) []string {
+	if schema == nil {
+		return nil
+	}
+	switch t := schema.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+// tipe utama schema (selain null)
+func primaryType(schema *Schema) string {
+	for _, t := range schemaTypes(schema) {
+		if t != "null" {
+			return t
+		}
+	}
+	return ""
+}
+
+func matchesType(types []string, value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// tipe JSON dari nilai hasil json.Unmarshal, angka bulat dianggap integer
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return ""
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// nama field bertingkat, contoh operations[0].data
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,278 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// satu operasi JSON Patch (RFC 6902)
+type Operation struct {
+	Op    string           `json:"op"`
+	Path  string           `json:"path"`
+	From  string           `json:"from,omitempty"`
+	Value *json.RawMessage `json:"value,omitempty"`
+}
+
+// terapkan JSON Patch ke dokumen, semua operasi atau tidak sama sekali
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("%w: dokumen: %v", ErrInvalidPatch, err)
+	}
+
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: JSON patch harus berupa array operasi: %v", ErrInvalidPatch, err)
+	}
+
+	var err error
+	for i, op := range ops {
+		target, err = apply(target, op)
+		if err != nil {
+			return nil, fmt.Errorf("operasi #%d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func apply(doc interface{}, op Operation) (interface{}, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: value wajib diisi", ErrInvalidPatch)
+		}
+		var value interface{}
+		if err := json.Unmarshal(*op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+
+		switch op.Op {
+		case "add":
+			return add(doc, op.Path, value)
+		case "replace":
+			if _, err := get(doc, op.Path); err != nil {
+				return nil, err
+			}
+			// path "" = ganti seluruh dokumen
+			if op.Path == "" {
+				return value, nil
+			}
+			doc, err := remove(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, op.Path, value)
+		default:
+			current, err := get(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return doc, nil
+		}
+
+	case "remove":
+		return remove(doc, op.Path)
+
+	case "move", "copy":
+		value, err := get(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, fmt.Errorf("%w: tidak bisa move ke dalam dirinya sendiri", ErrInvalidPatch)
+			}
+			if doc, err = remove(doc, op.From); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopy(value)
+		}
+		return add(doc, op.Path, value)
+	}
+
+	return nil, fmt.Errorf("%w: operasi '%s' tidak dikenal", ErrInvalidPatch, op.Op)
+}
+
+// pecah JSON pointer (RFC 6901) jadi token
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("%w: path '%s' harus diawali '/'", ErrInvalidPatch, path)
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc interface{}, path string) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := doc
+	for _, t := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			v, ok := node[t]
+			if !ok {
+				return nil, fmt.Errorf("%w: path '%s' tidak ditemukan", ErrInvalidPatch, path)
+			}
+			current = v
+		case []interface{}:
+			idx, err := arrayIndex(t, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = node[idx]
+		default:
+			return nil, fmt.Errorf("%w: path '%s' tidak ditemukan", ErrInvalidPatch, path)
+		}
+	}
+	return current, nil
+}
+
+func add(doc interface{}, path string, value interface{}) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return setIn(doc, tokens, value, path)
+}
+
+func setIn(node interface{}, tokens []string, value interface{}, path string) (interface{}, error) {
+	key := tokens[0]
+	last := len(tokens) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if last {
+			n[key] = value
+			return n, nil
+		}
+		child, ok := n[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: path '%s' tidak ditemukan", ErrInvalidPatch, path)
+		}
+		updated, err := setIn(child, tokens[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+		n[key] = updated
+		return n, nil
+
+	case []interface{}:
+		if last {
+			idx := len(n)
+			if key != "-" {
+				var err error
+				if idx, err = arrayIndex(key, len(n)); err != nil {
+					return nil, err
+				}
+			}
+			n = append(n, nil)
+			copy(n[idx+1:], n[idx:])
+			n[idx] = value
+			return n, nil
+		}
+		idx, err := arrayIndex(key, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := setIn(n[idx], tokens[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = updated
+		return n, nil
+	}
+
+	return nil, fmt.Errorf("%w: path '%s' tidak ditemukan", ErrInvalidPatch, path)
+}
+
+func remove(doc interface{}, path string) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: tidak bisa menghapus root dokumen", ErrInvalidPatch)
+	}
+	return removeIn(doc, tokens, path)
+}
+
+func removeIn(node interface{}, tokens []string, path string) (interface{}, error) {
+	key := tokens[0]
+	last := len(tokens) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: path '%s' tidak ditemukan", ErrInvalidPatch, path)
+		}
+		if last {
+			delete(n, key)
+			return n, nil
+		}
+		updated, err := removeIn(child, tokens[1:], path)
+		if err != nil {
+			return nil, err
+		}
+		n[key] = updated
+		return n, nil
+
+	case []interface{}:
+		idx, err := arrayIndex(key, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		if last {
+			return append(n[:idx], n[idx+1:]...), nil
+		}
+		updated, err := removeIn(n[idx], tokens[1:], path)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = updated
+		return n, nil
+	}
+
+	return nil, fmt.Errorf("%w: path '%s' tidak ditemukan", ErrInvalidPatch, path)
+}
+
+// index array valid 0..max, format RFC 6901: "0" atau [1-9][0-9]* (tanpa tanda +/-)
+func arrayIndex(token string, max int) (int, error) {
+	valid := token != "" && (token == "0" || token[0] != '0')
+	for i := 0; valid && i < len(token); i++ {
+		valid = token[i] >= '0' && token[i] <= '9'
+	}
+	idx, err := strconv.Atoi(token)
+	if !valid || err != nil || idx > max {
+		return 0, fmt.Errorf("%w: index array '%s' tidak valid", ErrInvalidPatch, token)
+	}
+	return idx, nil
+}
+
+func deepCopy(v interface{}) interface{} {
+	data, _ := json.Marshal(v)
+	var out interface{}
+	json.Unmarshal(data, &out)
+	return out
+}
@@ -0,0 +1,168 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONPatch(t *testing.T) {
+	const menu = `{"name":"Es Teh","price":5000,"ingredients":["teh","gula"],"meta":{"a/b":1,"m~n":2}}`
+
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "replace",
+			doc:   menu,
+			patch: `[{"op":"replace","path":"/price","value":6000}]`,
+			want:  `{"name":"Es Teh","price":6000,"ingredients":["teh","gula"],"meta":{"a/b":1,"m~n":2}}`,
+		},
+		{
+			name:  "add field & elemen array",
+			doc:   `{"ingredients":["teh","gula"]}`,
+			patch: `[{"op":"add","path":"/calories","value":90},{"op":"add","path":"/ingredients/1","value":"es"},{"op":"add","path":"/ingredients/-","value":"lemon"}]`,
+			want:  `{"calories":90,"ingredients":["teh","es","gula","lemon"]}`,
+		},
+		{
+			name:  "remove",
+			doc:   `{"name":"Es Teh","ingredients":["teh","gula","es"]}`,
+			patch: `[{"op":"remove","path":"/name"},{"op":"remove","path":"/ingredients/1"}]`,
+			want:  `{"ingredients":["teh","es"]}`,
+		},
+		{
+			name:  "move & copy",
+			doc:   `{"a":{"x":1},"b":{}}`,
+			patch: `[{"op":"copy","from":"/a/x","path":"/b/y"},{"op":"move","from":"/a","path":"/c"}]`,
+			want:  `{"b":{"y":1},"c":{"x":1}}`,
+		},
+		{
+			name:  "test lulus",
+			doc:   menu,
+			patch: `[{"op":"test","path":"/ingredients","value":["teh","gula"]},{"op":"replace","path":"/name","value":"Teh Manis"}]`,
+			want:  `{"name":"Teh Manis","price":5000,"ingredients":["teh","gula"],"meta":{"a/b":1,"m~n":2}}`,
+		},
+		{
+			name:  "escape pointer ~0 & ~1",
+			doc:   menu,
+			patch: `[{"op":"replace","path":"/meta/a~1b","value":10},{"op":"remove","path":"/meta/m~0n"}]`,
+			want:  `{"name":"Es Teh","price":5000,"ingredients":["teh","gula"],"meta":{"a/b":10}}`,
+		},
+		{
+			name:  "replace root",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"replace","path":"","value":{"b":2}}]`,
+			want:  `{"b":2}`,
+		},
+		{name: "test gagal", doc: menu, patch: `[{"op":"test","path":"/price","value":6000}]`, wantErr: ErrTestFailed},
+		{name: "replace path tidak ada", doc: menu, patch: `[{"op":"replace","path":"/calories","value":1}]`, wantErr: ErrInvalidPatch},
+		{name: "remove path tidak ada", doc: menu, patch: `[{"op":"remove","path":"/calories"}]`, wantErr: ErrInvalidPatch},
+		{name: "index di luar array", doc: menu, patch: `[{"op":"add","path":"/ingredients/5","value":"x"}]`, wantErr: ErrInvalidPatch},
+		{name: "index dengan tanda +", doc: menu, patch: `[{"op":"remove","path":"/ingredients/+1"}]`, wantErr: ErrInvalidPatch},
+		{name: "index -0", doc: menu, patch: `[{"op":"replace","path":"/ingredients/-0","value":"x"}]`, wantErr: ErrInvalidPatch},
+		{name: "index kosong", doc: menu, patch: `[{"op":"remove","path":"/ingredients/"}]`, wantErr: ErrInvalidPatch},
+		{name: "index diawali nol", doc: menu, patch: `[{"op":"remove","path":"/ingredients/01"}]`, wantErr: ErrInvalidPatch},
+		{name: "move ke dalam dirinya sendiri", doc: menu, patch: `[{"op":"move","from":"/meta","path":"/meta/x"}]`, wantErr: ErrInvalidPatch},
+		{name: "path tanpa /", doc: menu, patch: `[{"op":"remove","path":"name"}]`, wantErr: ErrInvalidPatch},
+		{name: "value tidak ada", doc: menu, patch: `[{"op":"add","path":"/x"}]`, wantErr: ErrInvalidPatch},
+		{name: "operasi tidak dikenal", doc: menu, patch: `[{"op":"merge","path":"/x","value":1}]`, wantErr: ErrInvalidPatch},
+		{name: "bukan array", doc: menu, patch: `{"op":"remove","path":"/name"}`, wantErr: ErrInvalidPatch},
+		{name: "hapus root", doc: menu, patch: `[{"op":"remove","path":""}]`, wantErr: ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPatch([]byte(tt.doc), []byte(tt.patch))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("JSONPatch: %v", err)
+			}
+			assertJSONEqual(t, got, tt.want)
+		})
+	}
+}
+
+// operasi yang gagal di tengah membatalkan semua operasi sebelumnya:
+// tidak ada dokumen setengah jadi yang dikembalikan
+func TestJSONPatchAtomic(t *testing.T) {
+	doc := []byte(`{"name":"Es Teh","price":5000,"ingredients":["teh"]}`)
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr error
+	}{
+		{name: "test gagal setelah replace", patch: `[{"op":"replace","path":"/name","value":"x"},{"op":"test","path":"/price","value":1}]`, wantErr: ErrTestFailed},
+		{name: "remove gagal setelah add", patch: `[{"op":"add","path":"/ingredients/-","value":"gula"},{"op":"remove","path":"/calories"}]`, wantErr: ErrInvalidPatch},
+		{name: "operasi rusak setelah move", patch: `[{"op":"move","from":"/name","path":"/title"},{"op":"add","path":"/x"}]`, wantErr: ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPatch(doc, []byte(tt.patch))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), "operasi #1") {
+				t.Fatalf("error = %v, want menyebut operasi #1", err)
+			}
+			if got != nil {
+				t.Fatalf("hasil = %s, want nil kalau ada operasi gagal", got)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{name: "ganti field", doc: `{"a":1,"b":2}`, patch: `{"a":3}`, want: `{"a":3,"b":2}`},
+		{name: "null menghapus", doc: `{"a":1,"b":2}`, patch: `{"b":null}`, want: `{"a":1}`},
+		{name: "object rekursif", doc: `{"m":{"x":1,"y":2}}`, patch: `{"m":{"y":null,"z":3}}`, want: `{"m":{"x":1,"z":3}}`},
+		{name: "array diganti utuh", doc: `{"i":["a","b"]}`, patch: `{"i":["c"]}`, want: `{"i":["c"]}`},
+		{name: "bukan object", doc: `{"a":1}`, patch: `["a"]`, wantErr: true},
+		{name: "JSON rusak", doc: `{"a":1}`, patch: `{"a":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Fatalf("error = %v, want ErrInvalidPatch", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergePatch: %v", err)
+			}
+			assertJSONEqual(t, got, tt.want)
+		})
+	}
+}
+
+func assertJSONEqual(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("hasil bukan JSON: %s", got)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("want bukan JSON: %s", want)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("hasil = %s, want %s", got, want)
+	}
+}
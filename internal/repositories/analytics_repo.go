@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ngehandle penyimpanan log pencarian
+type AnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// baris hasil agregasi per hari per query
+type DailyQueryStat struct {
+	Day          time.Time
+	Query        string
+	Count        int64
+	AvgResults   float64
+	AvgLatencyMs float64
+}
+
+// baris total per hari
+type DailyTotal struct {
+	Day   time.Time
+	Total int64
+	Zero  int64
+}
+
+// simpan log pencarian
+func (r *AnalyticsRepository) Create(entry *models.SearchLog) error {
+	return r.db.Create(entry).Error
+}
+
+// agregasi query per hari (hari di zona waktu from), zeroOnly = hanya query tanpa hasil
+func (r *AnalyticsRepository) DailyQueryStats(from, to time.Time, source string, zeroOnly bool) ([]DailyQueryStat, error) {
+	var stats []DailyQueryStat
+
+	day := dayExpr(from.Location())
+	query := r.scope(from, to, source).
+		Select(day + " AS day, normalized_query AS query, COUNT(*) AS count, AVG(result_count) AS avg_results, AVG(latency_ms) AS avg_latency_ms")
+	if zeroOnly {
+		query = query.Where("result_count = 0")
+	}
+
+	err := query.
+		Group(day + ", normalized_query").
+		Order(day + " DESC, count DESC, query ASC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// total pencarian & pencarian tanpa hasil per hari (hari di zona waktu from)
+func (r *AnalyticsRepository) DailyTotals(from, to time.Time, source string) ([]DailyTotal, error) {
+	var totals []DailyTotal
+	day := dayExpr(from.Location())
+	err := r.scope(from, to, source).
+		Select(day + " AS day, COUNT(*) AS total, SUM(CASE WHEN result_count = 0 THEN 1 ELSE 0 END) AS zero").
+		Group(day).
+		Order(day + " DESC").
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *AnalyticsRepository) scope(from, to time.Time, source string) *gorm.DB {
+	query := r.db.Model(&models.SearchLog{}).
+		Where("created_at >= ? AND created_at < ?", from, to)
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	return query
+}
+
+// tanggal created_at di zona waktu loc, bukan zona waktu session database
+func dayExpr(loc *time.Location) string {
+	return fmt.Sprintf("DATE(created_at AT TIME ZONE '%s')", strings.ReplaceAll(loc.String(), "'", "''"))
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// principal sudah punya role yang sama
+var ErrDuplicateRole = errors.New("role sudah diberikan")
+
+// ngehandle user lokal & API key
+type AuthRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthRepository(db *gorm.DB) *AuthRepository {
+	return &AuthRepository{db: db}
+}
+
+func (r *AuthRepository) FindUserByUsername(username string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *AuthRepository) GetUser(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// buat user atau ganti password user yang sudah ada (username sama)
+func (r *AuthRepository) SaveUser(user *models.User) error {
+	existing, err := r.FindUserByUsername(user.Username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.db.Create(user).Error
+	}
+	if err != nil {
+		return err
+	}
+	user.ID = existing.ID
+	user.CreatedAt = existing.CreatedAt
+	return r.db.Select("password_hash", "active", "updated_at").Save(user).Error
+}
+
+func (r *AuthRepository) CountUsers() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Count(&count).Error
+	return count, err
+}
+
+func (r *AuthRepository) CreateAPIKey(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *AuthRepository) FindAPIKeyByPrefix(prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *AuthRepository) GetAPIKey(id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *AuthRepository) ListAPIKeys() ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.Order("id ASC").Find(&keys).Error
+	return keys, err
+}
+
+// cabut key, key yang sudah dicabut tidak berubah
+func (r *AuthRepository) RevokeAPIKey(id uint, now time.Time) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	if key.RevokedAt == nil {
+		key.RevokedAt = &now
+		if err := r.db.Model(&key).Update("revoked_at", now).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &key, nil
+}
+
+// catat waktu terakhir key dipakai
+func (r *AuthRepository) TouchAPIKey(id uint, now time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", now).Error
+}
+
+// nama role principal (key mis. user:3)
+func (r *AuthRepository) RolesOf(principal string) ([]string, error) {
+	var roles []string
+	err := r.db.Model(&models.RoleAssignment{}).Where("principal = ?", principal).Order("role ASC").Pluck("role", &roles).Error
+	return roles, err
+}
+
+// role assignment, principal kosong = semua
+func (r *AuthRepository) ListRoleAssignments(principal string) ([]models.RoleAssignment, error) {
+	query := r.db.Order("principal ASC, role ASC")
+	if principal != "" {
+		query = query.Where("principal = ?", principal)
+	}
+	var assignments []models.RoleAssignment
+	err := query.Find(&assignments).Error
+	return assignments, err
+}
+
+// simpan role assignment, ErrDuplicateRole kalau principal sudah punya role itu
+func (r *AuthRepository) CreateRoleAssignment(assignment *models.RoleAssignment) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(assignment)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDuplicateRole
+	}
+	return nil
+}
+
+func (r *AuthRepository) GetRoleAssignment(id uint) (*models.RoleAssignment, error) {
+	var assignment models.RoleAssignment
+	if err := r.db.First(&assignment, id).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *AuthRepository) DeleteRoleAssignment(id uint) error {
+	return r.db.Delete(&models.RoleAssignment{}, id).Error
+}
+
+// jumlah principal dengan role tertentu
+func (r *AuthRepository) CountRole(role string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RoleAssignment{}).Where("role = ?", role).Count(&count).Error
+	return count, err
+}
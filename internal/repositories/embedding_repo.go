@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ngehandle penyimpanan vektor embedding menu
+type EmbeddingRepository struct {
+	db *gorm.DB
+}
+
+func NewEmbeddingRepository(db *gorm.DB) *EmbeddingRepository {
+	return &EmbeddingRepository{db: db}
+}
+
+// insert atau replace vektor menu
+func (r *EmbeddingRepository) Upsert(embedding *models.MenuEmbedding) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "menu_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"provider", "vector", "updated_at"}),
+	}).Create(embedding).Error
+}
+
+// semua vektor milik provider tertentu
+func (r *EmbeddingRepository) GetByProvider(provider string) ([]models.MenuEmbedding, error) {
+	var embeddings []models.MenuEmbedding
+	err := r.db.Where("provider = ?", provider).Find(&embeddings).Error
+	return embeddings, err
+}
+
+// hapus vektor menu
+func (r *EmbeddingRepository) Delete(menuID uint) error {
+	return r.db.Delete(&models.MenuEmbedding{}, menuID).Error
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"time"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ngehandle penyimpanan response per Idempotency-Key
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// record yang belum kadaluarsa
+func (r *IdempotencyRepository) Get(key string) (*models.IdempotencyRecord, error) {
+	var record models.IdempotencyRecord
+	err := r.db.Where("key = ? AND expires_at > ?", key, time.Now()).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// klaim key, false kalau key sudah dipakai request lain.
+// record kadaluarsa atau yang lease-nya habis tanpa response (proses mati di tengah
+// jalan) dengan key sama dihapus dulu supaya key bisa dipakai ulang
+func (r *IdempotencyRepository) Reserve(record *models.IdempotencyRecord) (bool, error) {
+	now := time.Now()
+	if err := r.db.Where("key = ? AND (expires_at <= ? OR (status_code = 0 AND locked_until <= ?))", record.Key, now, now).
+		Delete(&models.IdempotencyRecord{}).Error; err != nil {
+		return false, err
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// simpan response final
+func (r *IdempotencyRepository) Complete(record *models.IdempotencyRecord) error {
+	return r.db.Model(&models.IdempotencyRecord{}).
+		Where("key = ?", record.Key).
+		Updates(map[string]interface{}{
+			"status_code":  record.StatusCode,
+			"content_type": record.ContentType,
+			"etag":         record.ETag,
+			"body":         record.Body,
+		}).Error
+}
+
+// lepas key (request gagal, boleh dicoba lagi)
+func (r *IdempotencyRepository) Delete(key string) error {
+	return r.db.Where("key = ?", key).Delete(&models.IdempotencyRecord{}).Error
+}
+
+// bersihkan record kadaluarsa
+func (r *IdempotencyRepository) DeleteExpired() (int64, error) {
+	result := r.db.Where("expires_at <= ?", time.Now()).Delete(&models.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
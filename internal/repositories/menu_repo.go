@@ -1,14 +1,22 @@
 package repositories
 
 import (
-	"fmt"
-	"math"
+	"errors"
+	"GDGOC-API/internal/filterexpr"
 	"GDGOC-API/internal/models"
 	"strings"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// versi menu di db sudah berubah sejak dibaca
+var ErrVersionConflict = errors.New("versi menu sudah berubah")
+
+// nama menu bentrok dengan index unik nama
+var ErrDuplicateName = errors.New("nama menu sudah dipakai")
+
 // ngehandle semua operasi database untuk menus
 type MenuRepository struct {
 	db *gorm.DB
@@ -19,85 +27,267 @@ func NewMenuRepository(db *gorm.DB) *MenuRepository {
 	return &MenuRepository{db: db}
 }
 
+// jalankan fn dalam satu transaksi, repo yang diterima fn memakai tx tersebut
+func (r *MenuRepository) Transaction(fn func(repo *MenuRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&MenuRepository{db: tx})
+	})
+}
+
 // insert a menu baru ke db
 func (r *MenuRepository) Create(menu *models.Menu) error {
-	return r.db.Create(menu).Error
+	menu.NormalizedName = models.NormalizeMenuName(menu.Name)
+	return translateError(r.db.Create(menu).Error)
 }
 
 // return semua menu dgn opsi filters & pagination
 func (r *MenuRepository) GetAll(filters models.MenuFilters) ([]models.Menu, *models.PaginationMeta, error) {
-	var menus []models.Menu
-	var total int64
-
-	query := r.db.Model(&models.Menu{})
+	view, err := parseView(filters.View())
+	if err != nil {
+		return nil, nil, err
+	}
+	query, err := r.applyFilters(r.db.Model(&models.Menu{}), filters)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	query = r.applyFilters(query, filters)
+	return r.paginate(query, filters.PageParams(), filters.Query, view)
+}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, nil, err
+// iterasi semua menu hasil filter tanpa paging, urut sesuai sort. Filter & sort
+// divalidasi di sini, query baru dijalankan saat fungsi yang dikembalikan dipanggil
+func (r *MenuRepository) StreamAll(filters models.MenuFilters) (func(fn func(*models.Menu) error) error, error) {
+	query, err := r.applyFilters(r.db.Model(&models.Menu{}), filters)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseSort(filters.Sort, filters.Query)
+	if err != nil {
+		return nil, err
 	}
+	query = query.Clauses(orderClause(keys))
 
-	query = r.applySorting(query, filters.Sort)
+	return func(fn func(*models.Menu) error) error {
+		rows, err := query.Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var menu models.Menu
+			if err := r.db.ScanRows(rows, &menu); err != nil {
+				return err
+			}
+			if err := fn(&menu); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	}, nil
+}
 
-	page := filters.Page
-	perPage := filters.PerPage
+// GET berdasar ID
+func (r *MenuRepository) GetByID(id uint) (*models.Menu, error) {
+	var menu models.Menu
+	err := r.db.First(&menu, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
 
-	if page < 1 {
-		page = 1
+// GET berdasar ID dengan pilihan kolom & relasi, version selalu ikut (untuk ETag)
+func (r *MenuRepository) GetByIDView(id uint, view models.MenuView) (*models.Menu, error) {
+	parsed, err := parseView(view)
+	if err != nil {
+		return nil, err
 	}
-	if perPage < 1 {
-		perPage = 10 // default
+
+	var menu models.Menu
+	if err := parsed.apply(r.db, nil, "version").First(&menu, id).Error; err != nil {
+		return nil, err
 	}
-	if perPage > 100 {
-		perPage = 100 // max limit
+	return &menu, nil
+}
+
+// GET banyak menu sekaligus berdasar ID
+func (r *MenuRepository) GetByIDs(ids []uint) ([]models.Menu, error) {
+	var menus []models.Menu
+	if len(ids) == 0 {
+		return menus, nil
 	}
+	err := r.db.Where("id IN ?", ids).Find(&menus).Error
+	return menus, err
+}
 
-	offset := (page - 1) * perPage
-	query = query.Offset(offset).Limit(perPage)
+// iterasi semua menu per batch, fn dipanggil tiap batch
+func (r *MenuRepository) Each(batchSize int, fn func([]models.Menu) error) error {
+	var batch []models.Menu
+	return r.db.Model(&models.Menu{}).Order("id").FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
 
-	if err := query.Find(&menus).Error; err != nil {
-		return nil, nil, err
+// GET berdasar external ID dari sistem partner
+func (r *MenuRepository) FindByExternalID(externalID string) (*models.Menu, error) {
+	var menu models.Menu
+	err := r.db.Where("external_id = ?", externalID).First(&menu).Error
+	if err != nil {
+		return nil, err
 	}
+	return &menu, nil
+}
 
-	totalPages := int(math.Ceil(float64(total) / float64(perPage)))
-	pagination := &models.PaginationMeta{
-		Total:      total,
-		Page:       page,
-		PerPage:    perPage,
-		TotalPages: totalPages,
+// GET berdasar nama (tanpa beda huruf besar/kecil & spasi). Scope global = nama
+// unik di semua kategori, selain itu hanya dicari di kategori yang sama
+func (r *MenuRepository) FindByName(name, category, scope string) (*models.Menu, error) {
+	query := r.db.Where("normalized_name = ?", models.NormalizeMenuName(name))
+	if scope != models.UniqueNameGlobal {
+		query = query.Where("category = ?", category)
 	}
 
-	return menus, pagination, nil
+	var menu models.Menu
+	if err := query.Order("id").First(&menu).Error; err != nil {
+		return nil, err
+	}
+	return &menu, nil
 }
 
-// GET berdasar ID
-func (r *MenuRepository) GetByID(id uint) (*models.Menu, error) {
+// cari menu lain dengan nama (ternormalisasi) sama; scope category = hanya
+// di kategori yang sama, global = semua kategori. excludeID 0 = tanpa pengecualian
+func (r *MenuRepository) FindDuplicate(name, category, scope string, excludeID uint) (*models.Menu, error) {
+	query := r.db.Where("normalized_name = ?", models.NormalizeMenuName(name))
+	if scope == models.UniqueNameCategory {
+		query = query.Where("category = ?", category)
+	}
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+
 	var menu models.Menu
-	err := r.db.First(&menu, id).Error
-	if err != nil {
+	if err := query.Order("id").First(&menu).Error; err != nil {
 		return nil, err
 	}
 	return &menu, nil
 }
 
-// update menu yg sudah ada
+// id, nama & kategori semua menu (opsional satu kategori), untuk laporan duplikat
+func (r *MenuRepository) ListNames(category string) ([]models.Menu, error) {
+	query := r.db.Model(&models.Menu{}).Select("id", "name", "category").Order("id")
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var menus []models.Menu
+	err := query.Find(&menus).Error
+	return menus, err
+}
+
+// tambah hitungan view (dipakai untuk sort popularity), tanpa ubah updated_at
+func (r *MenuRepository) IncrementViews(id uint) error {
+	return r.db.Model(&models.Menu{}).Where("id = ?", id).
+		UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
+}
+
+// update menu yg sudah ada, hanya berhasil kalau versi di db masih sama
+// dengan menu.Version (optimistic locking); versi dinaikkan satu
 func (r *MenuRepository) Update(id uint, menu *models.Menu) error {
-	var existing models.Menu
-	if err := r.db.First(&existing, id).Error; err != nil {
+	current := menu.Version
+	menu.ID = id
+	menu.Version = current + 1
+	menu.NormalizedName = models.NormalizeMenuName(menu.Name)
+
+	result := r.db.Model(menu).
+		Select("external_id", "name", "normalized_name", "category", "calories", "price", "ingredients", "description", "version", "updated_at").
+		Where("version = ?", current).
+		Updates(menu)
+	if result.Error != nil {
+		menu.Version = current
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		menu.Version = current
+		return r.missingOrConflict(id)
+	}
+	return nil
+}
+
+// ganti semua varian menu
+func (r *MenuRepository) ReplaceVariants(menuID uint, variants []models.MenuVariant) error {
+	if err := r.db.Where("menu_id = ?", menuID).Delete(&models.MenuVariant{}).Error; err != nil {
 		return err
 	}
-	menu.ID = id
-	return r.db.Save(menu).Error
+	if len(variants) == 0 {
+		return nil
+	}
+	for i := range variants {
+		variants[i].MenuID = menuID
+	}
+	return r.db.Create(&variants).Error
 }
 
-//  hapus menu
-func (r *MenuRepository) Delete(id uint) error {
-	var menu models.Menu
-	if err := r.db.First(&menu, id).Error; err != nil {
+// ganti semua gambar menu
+func (r *MenuRepository) ReplaceImages(menuID uint, images []models.MenuImage) error {
+	if err := r.db.Where("menu_id = ?", menuID).Delete(&models.MenuImage{}).Error; err != nil {
 		return err
 	}
+	if len(images) == 0 {
+		return nil
+	}
+	for i := range images {
+		images[i].MenuID = menuID
+	}
+	return r.db.Create(&images).Error
+}
+
+// ganti tag menu, tag yang belum ada di tabel tags dibuat
+func (r *MenuRepository) ReplaceTags(menuID uint, names []string) error {
+	var tags []models.Tag
+	if len(names) > 0 {
+		create := make([]models.Tag, len(names))
+		for i, name := range names {
+			create[i] = models.Tag{Name: name}
+		}
+		err := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+			Create(&create).Error
+		if err != nil {
+			return err
+		}
+		if err := r.db.Where("name IN ?", names).Find(&tags).Error; err != nil {
+			return err
+		}
+	}
+	return r.db.Model(&models.Menu{ID: menuID}).Association("Tags").Replace(tags)
+}
 
-	return r.db.Delete(&menu).Error
+// hapus menu, version 0 = tanpa cek versi
+func (r *MenuRepository) Delete(id uint, version uint) error {
+	query := r.db.Where("id = ?", id)
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	result := query.Delete(&models.Menu{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.missingOrConflict(id)
+	}
+	return nil
+}
+
+// bedain menu sudah dihapus vs versi sudah berubah
+func (r *MenuRepository) missingOrConflict(id uint) error {
+	var count int64
+	if err := r.db.Model(&models.Menu{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return ErrVersionConflict
 }
 
 // ngelompokin menu by category
@@ -159,10 +349,7 @@ func (r *MenuRepository) GroupByCategory(mode string, perCategory int) (interfac
 }
 
 // Search
-func (r *MenuRepository) Search(query string, page, perPage int) ([]models.Menu, *models.PaginationMeta, error) {
-	var menus []models.Menu
-	var total int64
-
+func (r *MenuRepository) Search(query string, params models.PageParams) ([]models.Menu, *models.PaginationMeta, error) {
 	searchQuery := r.db.Model(&models.Menu{})
 
 	if query != "" {
@@ -174,43 +361,11 @@ func (r *MenuRepository) Search(query string, page, perPage int) ([]models.Menu,
 		)
 	}
 
-	// hitung total
-	if err := searchQuery.Count(&total).Error; err != nil {
-		return nil, nil, err
-	}
-
-	// Set defaults for pagination
-	if page < 1 {
-		page = 1
-	}
-	if perPage < 1 {
-		perPage = 10
-	}
-	if perPage > 100 {
-		perPage = 100
-	}
-
-	offset := (page - 1) * perPage
-	searchQuery = searchQuery.Offset(offset).Limit(perPage)
-
-	// eksekusi query
-	if err := searchQuery.Find(&menus).Error; err != nil {
-		return nil, nil, err
-	}
-
-	totalPages := int(math.Ceil(float64(total) / float64(perPage)))
-	pagination := &models.PaginationMeta{
-		Total:      total,
-		Page:       page,
-		PerPage:    perPage,
-		TotalPages: totalPages,
-	}
-
-	return menus, pagination, nil
+	return r.paginate(searchQuery, params, query, menuView{})
 }
 
 // filter query
-func (r *MenuRepository) applyFilters(query *gorm.DB, filters models.MenuFilters) *gorm.DB {
+func (r *MenuRepository) applyFilters(query *gorm.DB, filters models.MenuFilters) (*gorm.DB, error) {
 	if filters.Query != "" {
 		searchPattern := "%" + strings.ToLower(filters.Query) + "%"
 		query = query.Where(
@@ -237,39 +392,23 @@ func (r *MenuRepository) applyFilters(query *gorm.DB, filters models.MenuFilters
 		query = query.Where("calories <= ?", filters.MaxCalories)
 	}
 
-	return query
-}
-
-// sorting query
-func (r *MenuRepository) applySorting(query *gorm.DB, sort string) *gorm.DB {
-	if sort == "" {
-		// default sorting
-		return query.Order("created_at DESC")
-	}
-
-	parts := strings.Split(sort, ":")
-	if len(parts) != 2 {
-		return query.Order("created_at DESC")
-	}
-
-	field := parts[0]
-	direction := strings.ToUpper(parts[1])
-
-	if direction != "ASC" && direction != "DESC" {
-		direction = "ASC"
+	// filter expression (filter=...)
+	if filters.Filter != "" {
+		condition, args, err := filterexpr.Compile(filters.Filter)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, args...)
 	}
 
-	validFields := map[string]bool{
-		"name":       true,
-		"category":   true,
-		"price":      true,
-		"calories":   true,
-		"created_at": true,
-	}
+	return query, nil
+}
 
-	if validFields[field] {
-		return query.Order(fmt.Sprintf("%s %s", field, direction))
+// unique violation di index nama menu -> ErrDuplicateName
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.HasPrefix(pgErr.ConstraintName, "idx_menus_unique_name") {
+		return ErrDuplicateName
 	}
-
-	return query.Order("created_at DESC")
-}
\ No newline at end of file
+	return err
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"time"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ngehandle tabel outbox event perubahan menu
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// outbox yang memakai koneksi/transaksi repo menu ini, supaya event ikut
+// commit atau rollback bersama perubahan menunya
+func (r *MenuRepository) Outbox() *OutboxRepository {
+	return &OutboxRepository{db: r.db}
+}
+
+func (r *OutboxRepository) Append(event *models.OutboxEvent) error {
+	return r.db.Create(event).Error
+}
+
+// ambil event jatuh tempo yang belum terkirim dan tandai dengan lease, sama seperti
+// WebhookRepository.ClaimDue. Hanya event terlama yang belum terkirim per menu yang
+// diambil, event berikutnya menunggu sampai event sebelumnya terkirim (urutan per menu).
+// Event yang diparkir dilewati & tidak menahan event berikutnya
+func (r *OutboxRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Table("outbox_events AS o").
+			Where("o.published_at IS NULL AND o.parked_at IS NULL AND o.next_attempt_at <= ?", now).
+			Where(`NOT EXISTS (
+				SELECT 1 FROM outbox_events p
+				WHERE p.aggregate_id = o.aggregate_id AND p.published_at IS NULL AND p.parked_at IS NULL AND p.id < o.id
+			)`).
+			Order("o.id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		ids := make([]uint64, len(events))
+		for i := range events {
+			ids[i] = events[i].ID
+		}
+		return tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return events, err
+}
+
+// event sudah diterima semua sink
+func (r *OutboxRepository) MarkPublished(event *models.OutboxEvent, now time.Time) error {
+	event.PublishedAt = &now
+	return r.db.Model(event).Select("published_at", "sinks_done", "last_error").Updates(event).Error
+}
+
+// simpan hasil percobaan yang gagal: sink yang sudah berhasil & jadwal percobaan berikutnya
+func (r *OutboxRepository) MarkFailed(event *models.OutboxEvent) error {
+	return r.db.Model(event).Select("attempts", "next_attempt_at", "last_error", "sinks_done").Updates(event).Error
+}
+
+// event berhenti dicoba, disimpan untuk diperiksa manual
+func (r *OutboxRepository) MarkParked(event *models.OutboxEvent, now time.Time) error {
+	event.ParkedAt = &now
+	return r.db.Model(event).Select("attempts", "parked_at", "last_error", "sinks_done").Updates(event).Error
+}
+
+// hapus event yang sudah terkirim sebelum waktu tertentu
+func (r *OutboxRepository) DeletePublishedBefore(before time.Time) (int64, error) {
+	result := r.db.Where("published_at IS NOT NULL AND published_at < ?", before).Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
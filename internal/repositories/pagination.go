@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("cursor tidak valid")
+
+const (
+	cursorNext = "next"
+	cursorPrev = "prev"
+)
+
+// isi cursor (di-encode base64 JSON)
+type cursorToken struct {
+	Sort      string            `json:"s"`
+	Values    []json.RawMessage `json:"v"`
+	Direction string            `json:"d"`
+}
+
+// kondisi "baris setelah cursor" untuk urutan leksikografis keys
+func keysetCondition(keys []sortKey, values []interface{}) (string, []interface{}) {
+	var (
+		ors    []string
+		args   []interface{}
+		equals []string
+		eqArgs []interface{}
+	)
+
+	for i, k := range keys {
+		after, afterArgs := afterCondition(k, values[i])
+		if after != "" {
+			clause := append(append([]string{}, equals...), after)
+			ors = append(ors, "("+strings.Join(clause, " AND ")+")")
+			args = append(args, eqArgs...)
+			args = append(args, afterArgs...)
+		}
+
+		if values[i] == nil {
+			equals = append(equals, k.expr+" IS NULL")
+			eqArgs = append(eqArgs, k.args...)
+		} else {
+			equals = append(equals, k.expr+" = ?")
+			eqArgs = append(eqArgs, k.args...)
+			eqArgs = append(eqArgs, values[i])
+		}
+	}
+
+	if len(ors) == 0 {
+		return "1 = 0", nil
+	}
+	return strings.Join(ors, " OR "), args
+}
+
+// kondisi key k berada setelah value v sesuai arah & posisi NULL
+func afterCondition(k sortKey, v interface{}) (string, []interface{}) {
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+
+	if v == nil {
+		if k.nullsFirst {
+			return k.expr + " IS NOT NULL", k.args
+		}
+		return "", nil
+	}
+
+	args := append(append([]interface{}{}, k.args...), v)
+	if k.nullable && !k.nullsFirst {
+		return fmt.Sprintf("(%s %s ? OR %s IS NULL)", k.expr, op, k.expr), append(args, k.args...)
+	}
+	return fmt.Sprintf("%s %s ?", k.expr, op), args
+}
+
+func encodeCursor(keys []sortKey, menu models.Menu, direction string) string {
+	token := cursorToken{Sort: sortSignature(keys), Direction: direction}
+	for _, k := range keys {
+		raw, _ := json.Marshal(k.value(menu))
+		token.Values = append(token.Values, raw)
+	}
+
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string, keys []sortKey) ([]interface{}, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, "", ErrInvalidCursor
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, "", ErrInvalidCursor
+	}
+	if token.Sort != sortSignature(keys) || len(token.Values) != len(keys) {
+		return nil, "", ErrInvalidCursor
+	}
+	if token.Direction != cursorNext && token.Direction != cursorPrev {
+		return nil, "", ErrInvalidCursor
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		if string(token.Values[i]) == "null" {
+			if !k.nullable {
+				return nil, "", ErrInvalidCursor
+			}
+			continue
+		}
+		values[i], err = sortFields[k.field].decode(token.Values[i])
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+	}
+	return values, token.Direction, nil
+}
+
+func decodeString(raw json.RawMessage) (interface{}, error) {
+	var v string
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+func decodeFloat(raw json.RawMessage) (interface{}, error) {
+	var v float64
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+func decodeInt(raw json.RawMessage) (interface{}, error) {
+	var v int64
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+func decodeTime(raw json.RawMessage) (interface{}, error) {
+	var v time.Time
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+// jalankan query dengan pagination offset atau cursor (keyset),
+// searchText dipakai untuk sort relevance, view untuk fields= & include=
+func (r *MenuRepository) paginate(query *gorm.DB, params models.PageParams, searchText string, view menuView) ([]models.Menu, *models.PaginationMeta, error) {
+	keys, err := parseSort(params.Sort, searchText)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if params.PerPage < 1 {
+		params.PerPage = 10 // default
+	}
+	if params.PerPage > 100 {
+		params.PerPage = 100 // max limit
+	}
+
+	pagination := &models.PaginationMeta{PerPage: params.PerPage}
+
+	if params.WithTotal {
+		var total int64
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, nil, err
+		}
+		pagination.Total = &total
+		totalPages := int(math.Ceil(float64(total) / float64(params.PerPage)))
+		pagination.TotalPages = &totalPages
+	}
+
+	// select kolom & preload relasi setelah count
+	query = view.apply(query, keys)
+
+	if !params.CursorMode {
+		if params.Page < 1 {
+			params.Page = 1
+		}
+		pagination.Page = params.Page
+
+		var menus []models.Menu
+		offset := (params.Page - 1) * params.PerPage
+		err := query.Order(orderClause(keys)).Offset(offset).Limit(params.PerPage).Find(&menus).Error
+		if err != nil {
+			return nil, nil, err
+		}
+		return menus, pagination, nil
+	}
+
+	direction := cursorNext
+	queryKeys := keys
+	if params.Cursor != "" {
+		values, dir, err := decodeCursor(params.Cursor, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		direction = dir
+		if direction == cursorPrev {
+			queryKeys = reverseKeys(keys)
+		}
+		condition, args := keysetCondition(queryKeys, values)
+		query = query.Where(condition, args...)
+	}
+
+	// ambil satu ekstra untuk tahu masih ada halaman berikutnya
+	var menus []models.Menu
+	if err := query.Order(orderClause(queryKeys)).Limit(params.PerPage + 1).Find(&menus).Error; err != nil {
+		return nil, nil, err
+	}
+
+	hasMore := len(menus) > params.PerPage
+	if hasMore {
+		menus = menus[:params.PerPage]
+	}
+
+	if direction == cursorPrev {
+		for i, j := 0, len(menus)-1; i < j; i, j = i+1, j-1 {
+			menus[i], menus[j] = menus[j], menus[i]
+		}
+	}
+
+	if len(menus) > 0 {
+		hasNext := hasMore || direction == cursorPrev
+		hasPrev := params.Cursor != "" && (direction == cursorNext || hasMore)
+		if hasNext {
+			pagination.NextCursor = encodeCursor(keys, menus[len(menus)-1], cursorNext)
+		}
+		if hasPrev {
+			pagination.PrevCursor = encodeCursor(keys, menus[0], cursorPrev)
+		}
+	}
+
+	return menus, pagination, nil
+}
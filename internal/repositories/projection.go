@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidFields  = errors.New("fields tidak valid")
+	ErrInvalidInclude = errors.New("include tidak valid")
+)
+
+// field yang boleh dipilih lewat fields= (nama json = nama kolom)
+var selectableFields = []string{
+	"id", "external_id", "name", "category", "price", "calories", "ingredients",
+	"description", "view_count", "version", "created_at", "updated_at",
+}
+
+// relasi yang boleh di-embed lewat include= -> nama association GORM
+var includableRelations = map[string]string{
+	"variants": "Variants",
+	"tags":     "Tags",
+	"images":   "Images",
+}
+
+// hasil parsing fields= & include=, Fields kosong = semua kolom
+type menuView struct {
+	fields  []string
+	include []string
+}
+
+func parseView(view models.MenuView) (menuView, error) {
+	var (
+		parsed menuView
+		err    error
+	)
+	if parsed.fields, err = parseList(view.Fields, selectableFields, ErrInvalidFields); err != nil {
+		return parsed, err
+	}
+
+	relations := make([]string, 0, len(includableRelations))
+	for name := range includableRelations {
+		relations = append(relations, name)
+	}
+	if parsed.include, err = parseList(view.Include, relations, ErrInvalidInclude); err != nil {
+		return parsed, err
+	}
+	return parsed, nil
+}
+
+// daftar dipisah koma, divalidasi terhadap allow-list, duplikat dibuang
+func parseList(raw string, allowed []string, errKind error) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	valid := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		valid[name] = true
+	}
+
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if !valid[item] {
+			return nil, fmt.Errorf("%w: '%s' tidak dikenal", errKind, item)
+		}
+		if !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// pilih kolom di level SQL. Kolom yang dibutuhkan sort/cursor & id selalu ikut,
+// dibuang lagi saat proyeksi response di handler
+func (v menuView) apply(query *gorm.DB, keys []sortKey, extra ...string) *gorm.DB {
+	if len(v.fields) > 0 {
+		columns := append([]string{"id"}, extra...)
+		for _, k := range keys {
+			if k.field == "relevance" {
+				columns = append(columns, "name", "description", "ingredients")
+			} else if k.expr != "" {
+				columns = append(columns, k.expr)
+			}
+		}
+		columns = append(columns, v.fields...)
+		query = query.Select(uniqueStrings(columns))
+	}
+
+	for _, name := range v.include {
+		relation := includableRelations[name]
+		switch name {
+		case "images":
+			query = query.Preload(relation, func(db *gorm.DB) *gorm.DB {
+				return db.Order("position, id")
+			})
+		default:
+			query = query.Preload(relation, func(db *gorm.DB) *gorm.DB {
+				return db.Order("id")
+			})
+		}
+	}
+	return query
+}
+
+func uniqueStrings(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
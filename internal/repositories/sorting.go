@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidSort = errors.New("sort tidak valid")
+
+const maxSortKeys = 5
+
+// field yang boleh dipakai untuk sorting & keyset
+type sortField struct {
+	column      string
+	nullable    bool
+	defaultDesc bool
+	value       func(m models.Menu) interface{}
+	decode      func(raw json.RawMessage) (interface{}, error)
+}
+
+var sortFields = map[string]sortField{
+	"name":       {column: "name", value: func(m models.Menu) interface{} { return m.Name }, decode: decodeString},
+	"category":   {column: "category", value: func(m models.Menu) interface{} { return m.Category }, decode: decodeString},
+	"price":      {column: "price", value: func(m models.Menu) interface{} { return m.Price }, decode: decodeFloat},
+	"calories":   {column: "calories", nullable: true, value: func(m models.Menu) interface{} { return m.Calories }, decode: decodeInt},
+	"created_at": {column: "created_at", value: func(m models.Menu) interface{} { return m.CreatedAt }, decode: decodeTime},
+	"popularity": {column: "view_count", defaultDesc: true, value: func(m models.Menu) interface{} { return m.ViewCount }, decode: decodeInt},
+	"relevance":  {defaultDesc: true, decode: decodeInt},
+	"id":         {column: "id", value: func(m models.Menu) interface{} { return m.ID }, decode: decodeInt},
+}
+
+// satu key urutan, expr bisa berupa kolom atau ekspresi berparameter (relevance)
+type sortKey struct {
+	field      string
+	expr       string
+	args       []interface{}
+	desc       bool
+	nullable   bool
+	nullsFirst bool
+	value      func(m models.Menu) interface{}
+}
+
+// parsing sort "field[:asc|desc][:nulls_first|nulls_last]" dipisah koma,
+// id ditambahkan di akhir sebagai tiebreaker kalau belum ada
+func parseSort(sort, searchText string) ([]sortKey, error) {
+	sort = strings.TrimSpace(sort)
+	if sort == "" {
+		sort = "created_at:desc"
+	}
+
+	specs := strings.Split(sort, ",")
+	if len(specs) > maxSortKeys {
+		return nil, fmt.Errorf("%w: maksimal %d field", ErrInvalidSort, maxSortKeys)
+	}
+
+	var keys []sortKey
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		key, err := parseSortKey(strings.TrimSpace(spec), searchText)
+		if err != nil {
+			return nil, err
+		}
+		if seen[key.field] {
+			return nil, fmt.Errorf("%w: field '%s' disebut lebih dari sekali", ErrInvalidSort, key.field)
+		}
+		seen[key.field] = true
+		keys = append(keys, key)
+	}
+
+	if !seen["id"] {
+		keys = append(keys, columnKey("id", sortFields["id"], false))
+	}
+	return keys, nil
+}
+
+func parseSortKey(spec, searchText string) (sortKey, error) {
+	parts := strings.Split(strings.ToLower(spec), ":")
+	if len(parts) > 3 || parts[0] == "" {
+		return sortKey{}, fmt.Errorf("%w: format '%s' salah, gunakan field:asc|desc", ErrInvalidSort, spec)
+	}
+
+	name := parts[0]
+	field, ok := sortFields[name]
+	if !ok {
+		return sortKey{}, fmt.Errorf("%w: field '%s' tidak dikenal", ErrInvalidSort, name)
+	}
+
+	desc := field.defaultDesc
+	if len(parts) > 1 {
+		switch parts[1] {
+		case "asc":
+			desc = false
+		case "desc":
+			desc = true
+		default:
+			return sortKey{}, fmt.Errorf("%w: arah '%s' harus asc atau desc", ErrInvalidSort, parts[1])
+		}
+	}
+
+	var key sortKey
+	if name == "relevance" {
+		if strings.TrimSpace(searchText) == "" {
+			return sortKey{}, fmt.Errorf("%w: sort relevance membutuhkan query pencarian (q)", ErrInvalidSort)
+		}
+		key = relevanceKey(searchText, desc)
+	} else {
+		key = columnKey(name, field, desc)
+	}
+
+	if len(parts) == 3 {
+		if !key.nullable {
+			return sortKey{}, fmt.Errorf("%w: field '%s' tidak nullable", ErrInvalidSort, name)
+		}
+		switch parts[2] {
+		case "nulls_first":
+			key.nullsFirst = true
+		case "nulls_last":
+			key.nullsFirst = false
+		default:
+			return sortKey{}, fmt.Errorf("%w: '%s' harus nulls_first atau nulls_last", ErrInvalidSort, parts[2])
+		}
+	}
+	return key, nil
+}
+
+// default postgres: NULL dianggap paling besar
+func columnKey(name string, field sortField, desc bool) sortKey {
+	return sortKey{
+		field:      name,
+		expr:       field.column,
+		desc:       desc,
+		nullable:   field.nullable,
+		nullsFirst: field.nullable && desc,
+		value:      field.value,
+	}
+}
+
+// skor relevance: nama sama persis 4, nama mengandung query 2,
+// deskripsi & bahan masing-masing 1
+func relevanceKey(searchText string, desc bool) sortKey {
+	q := strings.ToLower(strings.TrimSpace(searchText))
+	pattern := "%" + escapeLike(q) + "%"
+
+	return sortKey{
+		field: "relevance",
+		expr: "(CASE WHEN LOWER(name) = ? THEN 4 ELSE 0 END" +
+			" + CASE WHEN LOWER(name) LIKE ? ESCAPE '\\' THEN 2 ELSE 0 END" +
+			" + CASE WHEN LOWER(description) LIKE ? ESCAPE '\\' THEN 1 ELSE 0 END" +
+			" + CASE WHEN EXISTS (SELECT 1 FROM unnest(ingredients) AS ing WHERE LOWER(ing) LIKE ? ESCAPE '\\') THEN 1 ELSE 0 END)",
+		args: []interface{}{q, pattern, pattern, pattern},
+		desc: desc,
+		value: func(m models.Menu) interface{} {
+			return relevanceScore(m, q)
+		},
+	}
+}
+
+// hitung skor relevance di Go, harus sama dengan ekspresi SQL di relevanceKey
+func relevanceScore(m models.Menu, q string) int64 {
+	var score int64
+	name := strings.ToLower(m.Name)
+	if name == q {
+		score += 4
+	}
+	if strings.Contains(name, q) {
+		score += 2
+	}
+	if strings.Contains(strings.ToLower(m.Description), q) {
+		score++
+	}
+	for _, ing := range m.Ingredients {
+		if strings.Contains(strings.ToLower(ing), q) {
+			score++
+			break
+		}
+	}
+	return score
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// string sort kanonik, dipakai buat cek cursor cocok dengan sort aktif
+func sortSignature(keys []sortKey) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		dir := "asc"
+		if k.desc {
+			dir = "desc"
+		}
+		parts[i] = k.field + ":" + dir
+		if k.nullable {
+			if k.nullsFirst {
+				parts[i] += ":nulls_first"
+			} else {
+				parts[i] += ":nulls_last"
+			}
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func orderClause(keys []sortKey) clause.OrderBy {
+	var (
+		parts []string
+		args  []interface{}
+	)
+	for _, k := range keys {
+		part := k.expr + " ASC"
+		if k.desc {
+			part = k.expr + " DESC"
+		}
+		if k.nullable {
+			if k.nullsFirst {
+				part += " NULLS FIRST"
+			} else {
+				part += " NULLS LAST"
+			}
+		}
+		parts = append(parts, part)
+		args = append(args, k.args...)
+	}
+
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                strings.Join(parts, ", "),
+		Vars:               args,
+		WithoutParentheses: true,
+	}}
+}
+
+// balik arah urutan untuk ambil halaman sebelumnya
+func reverseKeys(keys []sortKey) []sortKey {
+	reversed := make([]sortKey, len(keys))
+	for i, k := range keys {
+		reversed[i] = k
+		reversed[i].desc = !k.desc
+		reversed[i].nullsFirst = !k.nullsFirst
+	}
+	return reversed
+}
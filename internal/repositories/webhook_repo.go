@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"time"
+
+	"GDGOC-API/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ngehandle penyimpanan webhook, pengiriman & log percobaan
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *WebhookRepository) List() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *WebhookRepository) GetByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Select("url", "events", "description", "active", "updated_at").Save(webhook).Error
+}
+
+// hapus webhook beserta pengiriman & log-nya (cascade)
+func (r *WebhookRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Webhook{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// webhook aktif yang berlangganan event ini
+func (r *WebhookRepository) ActiveFor(eventType string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("active AND ? = ANY(events)", eventType).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *WebhookRepository) CreateDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Create(&deliveries).Error
+}
+
+// ambil pengiriman pending yang sudah jatuh tempo dan tandai dengan lease (next_attempt_at
+// dimundurkan) dalam satu transaksi, SKIP LOCKED supaya beberapa instance tidak mengirim
+// pengiriman yang sama. Kalau proses mati di tengah kirim, pengiriman diambil lagi setelah lease habis
+func (r *WebhookRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+// simpan hasil satu percobaan kirim: status pengiriman & log percobaan
+func (r *WebhookRepository) SaveAttempt(delivery *models.WebhookDelivery, entry *models.WebhookDeliveryLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(delivery).Select(
+			"status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "updated_at",
+		).Updates(delivery).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+// daftar pengiriman terbaru dulu, Status dead = dead-letter
+func (r *WebhookRepository) ListDeliveries(filters models.WebhookDeliveryFilters) ([]models.WebhookDelivery, int64, error) {
+	query := r.db.Model(&models.WebhookDelivery{})
+	if filters.WebhookID != 0 {
+		query = query.Where("webhook_id = ?", filters.WebhookID)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := query.Order("id DESC").
+		Offset((filters.Page - 1) * filters.PerPage).
+		Limit(filters.PerPage).
+		Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// pengiriman beserta log percobaan (urut waktu)
+func (r *WebhookRepository) GetDelivery(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Preload("Logs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// jadwalkan ulang pengiriman sekarang dengan jatah retry baru
+func (r *WebhookRepository) Reschedule(delivery *models.WebhookDelivery, now time.Time) error {
+	delivery.Status = models.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	return r.db.Model(delivery).Select("status", "attempts", "next_attempt_at", "updated_at").Updates(delivery).Error
+}
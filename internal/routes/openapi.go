@@ -0,0 +1,318 @@
+package routes
+
+import(
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/openapi"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
+	swaggerfiles "github.com/swaggo/files/v2"
+)
+
+// dokumentasi OpenAPI satu route, key di map docs = "METHOD path" sesuai route table
+type Doc struct{
+	Summary	string
+	Description	string
+	Tag	string
+	Params	[]*openapi.Parameter
+	Body	interface{}
+	// content type body, default application/json
+	BodyTypes	[]string
+	// schema body manual (multipart, patch), dipakai kalau Body tidak cukup
+	BodySchema	*openapi.Schema
+	Response	interface{}
+	// status sukses, default 200
+	Status	int
+	// content type response selain JSON (mis. export file)
+	ResponseTypes	[]string
+	// status lain yang didokumentasikan (selalu memakai Problem kecuali 207/304)
+	Errors	[]int
+}
+
+// lokasi spesifikasi & Swagger UI
+const(
+	SpecPath = APIV1 + "/openapi.json"
+	DocsPath = "/docs"
+)
+
+// swagger-initializer.js bawaan diganti supaya memuat spesifikasi API ini
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "` + SpecPath + `",
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// GET /api/v1/openapi.json & Swagger UI (asset di-embed, tanpa CDN) di /docs
+func mountDocs(app *fiber.App, spec *openapi.Document){
+	app.Get(SpecPath, func(c *fiber.Ctx) error{
+		return c.JSON(spec)
+	})
+
+	app.Get(DocsPath, func(c *fiber.Ctx) error{
+		return c.Redirect(DocsPath + "/", fiber.StatusMovedPermanently)
+	})
+	app.Get(DocsPath + "/swagger-initializer.js", func(c *fiber.Ctx) error{
+		c.Type("js")
+		return c.SendString(swaggerInitializer)
+	})
+	app.Use(DocsPath, filesystem.New(filesystem.Config{
+		Root: http.FS(swaggerfiles.FS),
+		Index: "index.html",
+	}))
+}
+
+// buat dokumen OpenAPI dari route table, error kalau ada route tanpa Doc
+// (atau Doc tanpa route) supaya dokumentasi tidak tertinggal dari kode
+func BuildSpec(routes []Route, docs map[string]Doc) (*openapi.Document, error){
+	gen := openapi.NewGenerator()
+	gen.Register(models.Menu{}, models.Problem{})
+
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title: "Menu Catalog API",
+			Description: "Katalog menu dengan pencarian, rekomendasi AI dan import/export.",
+			Version: "1.0.0",
+		},
+		Servers: []openapi.Server{{URL: APIV1}},
+		Paths: make(map[string]*openapi.PathItem),
+		Components: openapi.Components{Parameters: commonParameters(), SecuritySchemes: securitySchemes()},
+	}
+
+	var missing []string
+	used := make(map[string]bool)
+	tags := make(map[string]bool)
+	for _, r := range routes{
+		key := r.Method + " " + r.Path
+		d, ok := docs[key]
+		if !ok{
+			missing = append(missing, key)
+			continue
+		}
+		used[key] = true
+
+		path, pathParams := openapi.ConvertPath(r.Path)
+		item := doc.Paths[path]
+		if item == nil{
+			item = &openapi.PathItem{}
+			doc.Paths[path] = item
+		}
+		slot := item.Operation(r.Method)
+		if slot == nil{
+			return nil, fmt.Errorf("method %s belum didukung generator OpenAPI", r.Method)
+		}
+		*slot = buildOperation(gen, r, d, pathParams)
+		if d.Tag != ""{
+			tags[d.Tag] = true
+		}
+	}
+
+	var stale []string
+	for key := range docs{
+		if !used[key]{
+			stale = append(stale, key)
+		}
+	}
+	if len(missing) > 0 || len(stale) > 0{
+		sort.Strings(stale)
+		return nil, fmt.Errorf("route tanpa dokumentasi: [%s], dokumentasi tanpa route: [%s]",
+			strings.Join(missing, ", "), strings.Join(stale, ", "))
+	}
+	if refs := gen.MissingRefs(); len(refs) > 0{
+		return nil, fmt.Errorf("schema tidak ditemukan: %s", strings.Join(refs, ", "))
+	}
+
+	for tag := range tags{
+		doc.Tags = append(doc.Tags, openapi.Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool{ return doc.Tags[i].Name < doc.Tags[j].Name })
+	doc.Components.Schemas = gen.Components()
+	return doc, nil
+}
+
+// ID di path di-parse sebagai uint32 oleh handler
+var maxID float64 = math.MaxUint32
+
+func buildOperation(gen *openapi.Generator, r Route, d Doc, pathParams []string) *openapi.Operation{
+	op := &openapi.Operation{
+		OperationID: operationID(r),
+		Summary: d.Summary,
+		Description: d.Description,
+		Responses: make(map[string]*openapi.Response),
+	}
+	if d.Tag != ""{
+		op.Tags = []string{d.Tag}
+	}
+
+	for _, name := range pathParams{
+		op.Parameters = append(op.Parameters, &openapi.Parameter{
+			Name: name, In: "path", Required: true,
+			Schema: &openapi.Schema{Type: "integer", Minimum: new(float64), Maximum: &maxID},
+		})
+	}
+	op.Parameters = append(op.Parameters, d.Params...)
+	op.Parameters = append(op.Parameters, parameterRef("AcceptLanguage"))
+	if r.Method != fiber.MethodGet{
+		op.Parameters = append(op.Parameters, parameterRef("IdempotencyKey"))
+	}
+
+	// body request
+	if d.Body != nil || d.BodySchema != nil{
+		schema := d.BodySchema
+		if schema == nil{
+			schema = gen.Schema(d.Body)
+		}
+		types := d.BodyTypes
+		if len(types) == 0{
+			types = []string{fiber.MIMEApplicationJSON}
+		}
+		body := &openapi.RequestBody{Required: true, Content: make(map[string]*openapi.MediaType)}
+		for _, t := range types{
+			body.Content[t] = &openapi.MediaType{Schema: schema}
+		}
+		op.RequestBody = body
+	}
+
+	// response sukses
+	status := d.Status
+	if status == 0{
+		status = fiber.StatusOK
+	}
+	success := &openapi.Response{Description: http.StatusText(status)}
+	if d.Response != nil{
+		success.Content = map[string]*openapi.MediaType{
+			fiber.MIMEApplicationJSON: {Schema: gen.Schema(d.Response)},
+		}
+	}
+	if len(d.ResponseTypes) > 0{
+		success.Content = make(map[string]*openapi.MediaType)
+		for _, t := range d.ResponseTypes{
+			success.Content[t] = &openapi.MediaType{Schema: &openapi.Schema{Type: "string", Format: "binary"}}
+		}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	// response error (problem+json), 207 memakai schema sukses
+	problem := map[string]*openapi.MediaType{
+		"application/problem+json": {Schema: gen.Schema(models.Problem{})},
+	}
+	for _, code := range d.Errors{
+		response := &openapi.Response{Description: http.StatusText(code)}
+		switch{
+		case code == fiber.StatusMultiStatus:
+			response.Content = success.Content
+		case code >= fiber.StatusBadRequest:
+			response.Content = problem
+		}
+		op.Responses[strconv.Itoa(code)] = response
+	}
+	op.Responses["default"] = &openapi.Response{Description: "Error", Content: problem}
+
+	// semua route selain publicRoutes butuh token atau API key,
+	// permission dari routePermissions ditulis di deskripsi & diberi response 403
+	key := r.Method + " " + r.Path
+	if !publicRoutes[key]{
+		op.Security = []openapi.SecurityRequirement{{"bearerAuth": {}}, {"apiKeyAuth": {}}}
+		if _, ok := op.Responses["401"]; !ok{
+			op.Responses["401"] = &openapi.Response{Description: http.StatusText(fiber.StatusUnauthorized), Content: problem}
+		}
+		if perm := routePermissions[key]; perm != ""{
+			op.Description = strings.TrimSpace(op.Description + " Permission: " + perm + ".")
+			if _, ok := op.Responses["403"]; !ok{
+				op.Responses["403"] = &openapi.Response{Description: http.StatusText(fiber.StatusForbidden), Content: problem}
+			}
+		}
+	}
+	return op
+}
+
+// operationId dari method & path, contoh GET /menu/:id -> getMenuById
+func operationID(r Route) string{
+	var b strings.Builder
+	b.WriteString(strings.ToLower(r.Method))
+	for _, part := range strings.FieldsFunc(r.Path, func(c rune) bool{ return c == '/' || c == '-' }){
+		if strings.HasPrefix(part, ":"){
+			b.WriteString("By")
+			part = strings.TrimPrefix(part, ":")
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// parameter header yang dipakai banyak endpoint
+func commonParameters() map[string]*openapi.Parameter{
+	return map[string]*openapi.Parameter{
+		"AcceptLanguage": {
+			Name: fiber.HeaderAcceptLanguage, In: "header",
+			Description: "Bahasa pesan response (id atau en)",
+			Schema: &openapi.Schema{Type: "string", Example: "en"},
+		},
+		"IdempotencyKey": {
+			Name: "Idempotency-Key", In: "header",
+			Description: "Key unik untuk retry aman, response pertama dikirim ulang",
+			Schema: &openapi.Schema{Type: "string", MaxLength: intPtr(255)},
+		},
+		"IfMatch": {
+			Name: fiber.HeaderIfMatch, In: "header",
+			Description: "ETag menu dari GET, wajib kalau REQUIRE_IF_MATCH aktif",
+			Schema: &openapi.Schema{Type: "string"},
+		},
+	}
+}
+
+// token JWT dari POST /auth/token atau API key
+func securitySchemes() map[string]*openapi.SecurityScheme{
+	return map[string]*openapi.SecurityScheme{
+		"bearerAuth": {
+			Type: "http", Scheme: "bearer", BearerFormat: "JWT",
+			Description: "Access token dari POST /auth/token",
+		},
+		"apiKeyAuth": {
+			Type: "apiKey", In: "header", Name: "X-API-Key",
+			Description: "API key dari POST /auth/api-keys",
+		},
+	}
+}
+
+func parameterRef(name string) *openapi.Parameter{
+	return &openapi.Parameter{Ref: "#/components/parameters/" + name}
+}
+
+// parameter query
+func query(name, typ, description string, enum ...interface{}) *openapi.Parameter{
+	return &openapi.Parameter{
+		Name: name,
+		In: "query",
+		Description: description,
+		Schema: &openapi.Schema{Type: typ, Enum: enum},
+	}
+}
+
+// parameter query wajib
+func requiredQuery(name, typ, description string) *openapi.Parameter{
+	param := query(name, typ, description)
+	param.Required = true
+	return param
+}
+
+// parameter header
+func header(name, description string) *openapi.Parameter{
+	return &openapi.Parameter{Name: name, In: "header", Description: description, Schema: &openapi.Schema{Type: "string"}}
+}
+
+func intPtr(v int) *int{
+	return &v
+}
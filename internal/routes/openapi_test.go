@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestBuildSpecDocumentsEveryRoute(t *testing.T) {
+	spec, err := BuildSpec(V1Routes(nil, nil, nil, nil, nil, nil), V1Docs())
+	if err != nil {
+		t.Fatalf("BuildSpec: %v", err)
+	}
+	if len(spec.Paths) == 0 {
+		t.Fatal("spesifikasi tanpa path")
+	}
+}
+
+func TestBuildSpecRejectsUndocumentedRoutes(t *testing.T) {
+	noop := func(c *fiber.Ctx) error { return nil }
+	docs := V1Docs()
+	docs["GET /stale"] = Doc{Summary: "route sudah dihapus"}
+
+	tests := []struct {
+		name   string
+		routes []Route
+		docs   map[string]Doc
+		want   string
+	}{
+		{
+			name:   "route tanpa dokumentasi",
+			routes: append(V1Routes(nil, nil, nil, nil, nil, nil), Route{fiber.MethodGet, "/undocumented", noop}),
+			docs:   V1Docs(),
+			want:   "GET /undocumented",
+		},
+		{
+			name:   "dokumentasi tanpa route",
+			routes: V1Routes(nil, nil, nil, nil, nil, nil),
+			docs:   docs,
+			want:   "GET /stale",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildSpec(tt.routes, tt.docs)
+			if err == nil {
+				t.Fatal("BuildSpec harus gagal")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error %q tidak menyebut %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,32 +1,133 @@
 package routes
 
 import(
+	"GDGOC-API/internal/config"
 	"GDGOC-API/internal/handlers"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/middleware"
+	"GDGOC-API/internal/openapi"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// prefix versi API yang aktif
+const APIV1 = "/api/v1"
+
+// satu endpoint API
+type Route struct{
+	Method	string
+	Path	string
+	Handler	fiber.Handler
+}
+
 // setup
-func SetupRoutes(app *fiber.App, menuHandler *handlers.MenuHandler){
+func SetupRoutes(app *fiber.App, menuHandler *handlers.MenuHandler, analyticsHandler *handlers.AnalyticsHandler, graphqlHandler *handlers.GraphQLHandler, eventHandler *handlers.EventHandler, webhookHandler *handlers.WebhookHandler, authHandler *handlers.AuthHandler){
 	app.Get("/health", func(c *fiber.Ctx) error{
 		return c.JSON(fiber.Map{
 			"status": "ok",
-			"message": "menu API berjalan",
+			"message": i18n.T(i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage), i18n.Default()), i18n.MsgAPIRunning),
 		})
 	})
 
-	setupMenuRoutes(app, menuHandler)
+	v1 := V1Routes(menuHandler, analyticsHandler, graphqlHandler, eventHandler, webhookHandler, authHandler)
+
+	// spesifikasi OpenAPI & Swagger UI, server tidak jalan kalau ada route tanpa dokumentasi
+	spec, err := BuildSpec(v1, V1Docs())
+	if err != nil{
+		log.Fatalf("Dokumentasi OpenAPI tidak lengkap: %v", err)
+	}
+	mountDocs(app, spec)
+
+	// autentikasi dicek sebelum request divalidasi terhadap spesifikasi
+	cfg := config.GetConfig()
+	guards, err := authGuards(v1, cfg.AuthEnabled)
+	if err != nil{
+		log.Fatalf("Permission route tidak lengkap: %v", err)
+	}
+	validators := requestValidators(v1, spec)
+	mount(app.Group(APIV1), v1, guards, validators)
+
+	// route lama tanpa prefix tetap jalan sebagai alias v1 yang deprecated
+	if cfg.LegacyRoutes{
+		mount(app, v1, guards, validators, middleware.Deprecated(parseDate(cfg.LegacyDeprecatedAt), parseDate(cfg.LegacySunset), APIV1))
+	}
 }
 
-func setupMenuRoutes(router fiber.Router, handler *handlers.MenuHandler){
-	// menu route
-	router.Post("/menu/recommendations", handler.GetRecommendations)
-	router.Get("/menu/group-by-category", handler.GroupByCategory)
-	router.Get("/menu/search", handler.SearchMenus)
-	router.Post("/menu", handler.CreateMenu)
-	router.Get("/menu", handler.GetAllMenus)
-	router.Get("/menu/:id", handler.GetMenuByID)
-	router.Put("/menu/:id", handler.UpdateMenu)
-	router.Delete("/menu/:id", handler.DeleteMenu)
-	
-}
\ No newline at end of file
+// pasang daftar route ke router, middleware dijalankan per route sebelum
+// guard, validator (key "METHOD path") dan handler
+func mount(router fiber.Router, routes []Route, guards, validators map[string]fiber.Handler, middleware ...fiber.Handler){
+	for _, r := range routes{
+		key := r.Method + " " + r.Path
+		handlers := append([]fiber.Handler{}, middleware...)
+		if guard, ok := guards[key]; ok{
+			handlers = append(handlers, guard)
+		}
+		if validate, ok := validators[key]; ok{
+			handlers = append(handlers, validate)
+		}
+		router.Add(r.Method, r.Path, append(handlers, r.Handler)...)
+	}
+}
+
+// route selain publicRoutes wajib terautentikasi & punya permission di routePermissions,
+// enabled false = semua route terbuka. Error kalau ada route tanpa entry permission
+func authGuards(routes []Route, enabled bool) (map[string]fiber.Handler, error){
+	guards := make(map[string]fiber.Handler)
+	var missing []string
+	requireAuth := middleware.RequireAuth()
+	for _, r := range routes{
+		key := r.Method + " " + r.Path
+		if publicRoutes[key]{
+			continue
+		}
+		perm, ok := routePermissions[key]
+		switch{
+		case !ok:
+			missing = append(missing, key)
+		case perm == "":
+			guards[key] = requireAuth
+		default:
+			guards[key] = middleware.RequirePermission(perm)
+		}
+	}
+	if len(missing) > 0{
+		return nil, fmt.Errorf("route tanpa permission: %s", strings.Join(missing, ", "))
+	}
+	if !enabled{
+		return map[string]fiber.Handler{}, nil
+	}
+	return guards, nil
+}
+
+// middleware validasi per route dari operasi di spesifikasi
+func requestValidators(routes []Route, spec *openapi.Document) map[string]fiber.Handler{
+	validators := make(map[string]fiber.Handler)
+	for _, r := range routes{
+		path, _ := openapi.ConvertPath(r.Path)
+		item, ok := spec.Paths[path]
+		if !ok{
+			continue
+		}
+		if op := *item.Operation(r.Method); op != nil{
+			validators[r.Method + " " + r.Path] = middleware.ValidateRequest(spec, op)
+		}
+	}
+	return validators
+}
+
+// tanggal YYYY-MM-DD dari config, kosong/invalid = tidak dikirim
+func parseDate(value string) time.Time{
+	if value == ""{
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil{
+		log.Printf("Tanggal '%s' tidak valid (format YYYY-MM-DD): %v", value, err)
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,139 @@
+package routes
+
+import(
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/handlers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// endpoint v1, response memakai DTO di package models. Versi berikutnya dibuat
+// di file terpisah (v2.go) dengan handler & DTO sendiri lalu dipasang di /api/v2,
+// jadi v1 tidak ikut berubah
+func V1Routes(menuHandler *handlers.MenuHandler, analyticsHandler *handlers.AnalyticsHandler, graphqlHandler *handlers.GraphQLHandler, eventHandler *handlers.EventHandler, webhookHandler *handlers.WebhookHandler, authHandler *handlers.AuthHandler) []Route{
+	routes := authRoutes(authHandler)
+	// feed perubahan di bawah /menu, harus sebelum /menu/:id
+	routes = append(routes, eventRoutes(eventHandler)...)
+	routes = append(routes, menuRoutes(menuHandler)...)
+	routes = append(routes, adminRoutes(menuHandler, analyticsHandler)...)
+	routes = append(routes, webhookRoutes(webhookHandler)...)
+	return append(routes, Route{fiber.MethodPost, "/graphql", graphqlHandler.Query})
+}
+
+// route v1 yang bisa dipanggil tanpa autentikasi, key "METHOD path"
+var publicRoutes = map[string]bool{
+	fiber.MethodPost + " /auth/token": true,
+}
+
+// permission yang dibutuhkan tiap route v1 selain publicRoutes, key "METHOD path".
+// "" = cukup terautentikasi. Server tidak jalan kalau ada route yang tidak terdaftar.
+// Perubahan harga (PUT/PATCH/bulk/import) & operasi delete di bulk dicek lagi di MenuService,
+// mutation GraphQL dicek di resolver
+var routePermissions = map[string]string{
+	"GET /auth/me": "",
+	"POST /auth/api-keys": auth.PermAuthManage,
+	"GET /auth/api-keys": auth.PermAuthManage,
+	"DELETE /auth/api-keys/:id": auth.PermAuthManage,
+	"GET /auth/roles": auth.PermAuthManage,
+	"GET /auth/role-assignments": auth.PermAuthManage,
+	"POST /auth/role-assignments": auth.PermAuthManage,
+	"DELETE /auth/role-assignments/:id": auth.PermAuthManage,
+
+	"GET /menu/events": auth.PermMenuRead,
+	"GET /menu/events/ws": auth.PermMenuRead,
+
+	"POST /menu/recommendations": auth.PermAIUse,
+	"GET /menu/group-by-category": auth.PermMenuRead,
+	"GET /menu/search": auth.PermMenuRead,
+	"GET /menu/semantic-search": auth.PermMenuRead,
+	"POST /menu/bulk": auth.PermMenuWrite,
+	"POST /menu/import": auth.PermMenuWrite,
+	"GET /menu/export": auth.PermMenuRead,
+	"POST /menu": auth.PermMenuWrite,
+	"GET /menu": auth.PermMenuRead,
+	"GET /menu/:id": auth.PermMenuRead,
+	"PUT /menu/:id": auth.PermMenuWrite,
+	"PUT /menu/:id/relations": auth.PermMenuWrite,
+	"PATCH /menu/:id": auth.PermMenuWrite,
+	"DELETE /menu/:id": auth.PermMenuDelete,
+
+	"GET /admin/search-report": auth.PermReportRead,
+	"GET /admin/duplicates": auth.PermReportRead,
+
+	"POST /webhooks": auth.PermWebhookManage,
+	"GET /webhooks": auth.PermWebhookManage,
+	"GET /webhooks/deliveries": auth.PermWebhookManage,
+	"GET /webhooks/deliveries/:id": auth.PermWebhookManage,
+	"POST /webhooks/deliveries/:id/redeliver": auth.PermWebhookManage,
+	"GET /webhooks/:id": auth.PermWebhookManage,
+	"PUT /webhooks/:id": auth.PermWebhookManage,
+	"DELETE /webhooks/:id": auth.PermWebhookManage,
+	"POST /webhooks/:id/ping": auth.PermWebhookManage,
+	"GET /webhooks/:id/deliveries": auth.PermWebhookManage,
+
+	"POST /graphql": auth.PermMenuRead,
+}
+
+func authRoutes(handler *handlers.AuthHandler) []Route{
+	return []Route{
+		{fiber.MethodPost, "/auth/token", handler.Token},
+		{fiber.MethodGet, "/auth/me", handler.Me},
+		{fiber.MethodPost, "/auth/api-keys", handler.CreateAPIKey},
+		{fiber.MethodGet, "/auth/api-keys", handler.ListAPIKeys},
+		{fiber.MethodDelete, "/auth/api-keys/:id", handler.RevokeAPIKey},
+		{fiber.MethodGet, "/auth/roles", handler.ListRoles},
+		{fiber.MethodGet, "/auth/role-assignments", handler.ListRoleAssignments},
+		{fiber.MethodPost, "/auth/role-assignments", handler.AssignRole},
+		{fiber.MethodDelete, "/auth/role-assignments/:id", handler.UnassignRole},
+	}
+}
+
+func eventRoutes(handler *handlers.EventHandler) []Route{
+	return []Route{
+		{fiber.MethodGet, "/menu/events", handler.Stream},
+		{fiber.MethodGet, "/menu/events/ws", handler.WebSocket},
+	}
+}
+
+func menuRoutes(handler *handlers.MenuHandler) []Route{
+	// route statis harus sebelum /menu/:id
+	return []Route{
+		{fiber.MethodPost, "/menu/recommendations", handler.GetRecommendations},
+		{fiber.MethodGet, "/menu/group-by-category", handler.GroupByCategory},
+		{fiber.MethodGet, "/menu/search", handler.SearchMenus},
+		{fiber.MethodGet, "/menu/semantic-search", handler.SemanticSearch},
+		{fiber.MethodPost, "/menu/bulk", handler.BulkMenus},
+		{fiber.MethodPost, "/menu/import", handler.ImportMenus},
+		{fiber.MethodGet, "/menu/export", handler.ExportMenus},
+		{fiber.MethodPost, "/menu", handler.CreateMenu},
+		{fiber.MethodGet, "/menu", handler.GetAllMenus},
+		{fiber.MethodGet, "/menu/:id", handler.GetMenuByID},
+		{fiber.MethodPut, "/menu/:id", handler.UpdateMenu},
+		{fiber.MethodPut, "/menu/:id/relations", handler.UpdateMenuRelations},
+		{fiber.MethodPatch, "/menu/:id", handler.PatchMenu},
+		{fiber.MethodDelete, "/menu/:id", handler.DeleteMenu},
+	}
+}
+
+func adminRoutes(menuHandler *handlers.MenuHandler, analyticsHandler *handlers.AnalyticsHandler) []Route{
+	return []Route{
+		{fiber.MethodGet, "/admin/search-report", analyticsHandler.SearchReport},
+		{fiber.MethodGet, "/admin/duplicates", menuHandler.NearDuplicates},
+	}
+}
+
+func webhookRoutes(handler *handlers.WebhookHandler) []Route{
+	// route statis harus sebelum /webhooks/:id
+	return []Route{
+		{fiber.MethodPost, "/webhooks", handler.Create},
+		{fiber.MethodGet, "/webhooks", handler.List},
+		{fiber.MethodGet, "/webhooks/deliveries", handler.Deliveries},
+		{fiber.MethodGet, "/webhooks/deliveries/:id", handler.GetDelivery},
+		{fiber.MethodPost, "/webhooks/deliveries/:id/redeliver", handler.Redeliver},
+		{fiber.MethodGet, "/webhooks/:id", handler.Get},
+		{fiber.MethodPut, "/webhooks/:id", handler.Update},
+		{fiber.MethodDelete, "/webhooks/:id", handler.Delete},
+		{fiber.MethodPost, "/webhooks/:id/ping", handler.Ping},
+		{fiber.MethodGet, "/webhooks/:id/deliveries", handler.WebhookDeliveries},
+	}
+}
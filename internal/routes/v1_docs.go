@@ -0,0 +1,406 @@
+package routes
+
+import(
+	"GDGOC-API/internal/catalog"
+	"GDGOC-API/internal/gemini"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/openapi"
+	"GDGOC-API/internal/patch"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// dokumentasi route v1, setiap route di V1Routes wajib punya entry di sini
+func V1Docs() map[string]Doc{
+	return map[string]Doc{
+		"GET /menu/events": {
+			Summary: "Stream perubahan katalog (Server-Sent Events)",
+			Description: "Event menu.created, menu.updated dan menu.deleted dengan id <epoch>-<urutan>, data berisi snapshot menu. " +
+				"Reconnect dengan Last-Event-ID melanjutkan dari log event terakhir (EVENT_LOG_SIZE); kalau event sudah tidak ada di log " +
+				"dikirim event reset, client ambil ulang GET /menu lalu lanjut dari id reset.",
+			Tag: "events",
+			Params: feedParams(),
+			ResponseTypes: []string{"text/event-stream"},
+			Errors: []int{400},
+		},
+		"GET /menu/events/ws": {
+			Summary: "Stream perubahan katalog (WebSocket)",
+			Description: "Upgrade ke WebSocket, setiap pesan berisi satu event JSON (sama dengan data SSE) atau pesan reset. " +
+				"Stream yang tertinggal ditutup dengan close code 1013, reconnect dengan last_event_id.",
+			Tag: "events",
+			Params: feedParams(),
+			Status: fiber.StatusSwitchingProtocols,
+			Errors: []int{400, 426},
+		},
+		"POST /menu/recommendations": {
+			Summary: "Rekomendasi menu dengan AI (fallback keyword tanpa Gemini)",
+			Tag: "ai",
+			Body: gemini.RecommendationReq{},
+			Response: gemini.RecommendationResult{},
+			Errors: []int{400},
+		},
+		"GET /menu/group-by-category": {
+			Summary: "Kelompokkan menu per kategori",
+			Tag: "menu",
+			Params: []*openapi.Parameter{
+				query("mode", "string", "count (jumlah per kategori) atau list (daftar menu)", "count", "list"),
+				query("per_category", "integer", "Jumlah menu per kategori untuk mode list (default 10, max 100)"),
+			},
+			Response: models.GroupByCategoryResponse{},
+		},
+		"GET /menu/search": {
+			Summary: "Full-text search menu",
+			Tag: "search",
+			Params: append([]*openapi.Parameter{
+				query("q", "string", "Kata kunci"),
+			}, pageParams()...),
+			Response: models.MenuListResponse{},
+			Errors: []int{400},
+		},
+		"GET /menu/semantic-search": {
+			Summary: "Pencarian semantik (vector) atau hybrid",
+			Tag: "search",
+			Params: []*openapi.Parameter{
+				requiredQuery("q", "string", "Kalimat pencarian"),
+				query("mode", "string", "vector atau hybrid", "vector", "hybrid"),
+				query("limit", "integer", "Jumlah hasil (default 10, max 100)"),
+				query("alpha", "number", "Bobot vector pada mode hybrid (0-1)"),
+			},
+			Response: models.SemanticSearchResponse{},
+			Errors: []int{400, 503},
+		},
+		"POST /menu/bulk": {
+			Summary: "Create/update/delete banyak menu sekaligus",
+			Description: "Operasi delete butuh menu:delete dan update yang mengubah harga butuh price:write, operasi tanpa izin gagal dengan code 403.",
+			Tag: "menu",
+			Body: models.BulkRequest{},
+			Response: models.BulkResponse{},
+			Errors: []int{207, 400, 422},
+		},
+		"POST /menu/import": {
+			Summary: "Import katalog menu dari CSV/XLSX/JSONL",
+			Description: "Baris yang mengubah harga menu yang sudah ada gagal tanpa permission price:write.",
+			Tag: "catalog",
+			Params: []*openapi.Parameter{
+				query("format", "string", "Format file, default dari ekstensi", catalog.FormatCSV, catalog.FormatXLSX, catalog.FormatJSONL),
+				query("dry_run", "boolean", "Hitung hasil tanpa menyimpan"),
+				query("upsert_by", "string", "Kunci upsert", models.ImportUpsertByName, models.ImportUpsertByExternalID),
+			},
+			BodyTypes: []string{fiber.MIMEMultipartForm},
+			BodySchema: &openapi.Schema{
+				Type: "object",
+				Required: []string{"file"},
+				Properties: map[string]*openapi.Schema{
+					"file": {Type: "string", Format: "binary"},
+				},
+			},
+			Response: models.ImportReport{},
+			Errors: []int{207, 400},
+		},
+		"GET /menu/export": {
+			Summary: "Export menu hasil filter (streaming)",
+			Tag: "catalog",
+			Params: append([]*openapi.Parameter{
+				query("format", "string", "Format file", catalog.FormatCSV, catalog.FormatJSONL, catalog.FormatXLSX),
+				query("columns", "string", "Daftar kolom dipisah koma: "+strings.Join(catalog.ExportColumns, ", ")),
+			}, filterParams()...),
+			ResponseTypes: []string{
+				"text/csv",
+				"application/x-ndjson",
+				"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			},
+			Errors: []int{400},
+		},
+		"POST /menu": {
+			Summary: "Buat menu baru",
+			Tag: "menu",
+			Body: models.CreateMenuRequest{},
+			Response: models.MenuResponse{},
+			Status: fiber.StatusCreated,
+			Errors: []int{400, 409},
+		},
+		"GET /menu": {
+			Summary: "Daftar menu dengan filter, sorting & pagination",
+			Tag: "menu",
+			Params: append(append(filterParams(), pageParams()[1:]...), viewParams()...),
+			Response: models.MenuListResponse{},
+			Errors: []int{400},
+		},
+		"GET /menu/:id": {
+			Summary: "Detail menu",
+			Tag: "menu",
+			Params: append(viewParams(), header(fiber.HeaderIfNoneMatch, "ETag dari response sebelumnya, 304 kalau belum berubah")),
+			Response: models.MenuResponse{},
+			Errors: []int{304, 400, 404},
+		},
+		"PUT /menu/:id": {
+			Summary: "Ganti seluruh data menu",
+			Description: "Mengubah harga butuh permission price:write.",
+			Tag: "menu",
+			Params: []*openapi.Parameter{parameterRef("IfMatch")},
+			Body: models.UpdateMenuRequest{},
+			Response: models.MenuResponse{},
+			Errors: []int{400, 404, 409, 412, 428},
+		},
+		"PUT /menu/:id/relations": {
+			Summary: "Ganti varian, tag dan gambar menu",
+			Description: "Field yang dikirim menggantikan seluruh isi relasi tersebut, field yang tidak dikirim tidak berubah. " +
+				"Mengubah varian butuh permission price:write.",
+			Tag: "menu",
+			Params: []*openapi.Parameter{parameterRef("IfMatch")},
+			Body: models.UpdateMenuRelationsRequest{},
+			Response: models.MenuResponse{},
+			Errors: []int{400, 404, 409, 412, 428},
+		},
+		"PATCH /menu/:id": {
+			Summary: "Ubah sebagian data menu (JSON Merge Patch / JSON Patch)",
+			Description: "Mengubah harga butuh permission price:write.",
+			Tag: "menu",
+			Params: []*openapi.Parameter{parameterRef("IfMatch")},
+			BodyTypes: []string{patch.ContentTypeMergePatch, patch.ContentTypeJSONPatch, fiber.MIMEApplicationJSON},
+			BodySchema: &openapi.Schema{OneOf: []*openapi.Schema{
+				{Type: "object", Description: "JSON Merge Patch (RFC 7396)"},
+				{Type: "array", Description: "JSON Patch (RFC 6902)", Items: &openapi.Schema{
+					Type: "object",
+					Required: []string{"op", "path"},
+					Properties: map[string]*openapi.Schema{
+						"op": {Type: "string", Enum: []interface{}{"add", "remove", "replace", "move", "copy", "test"}},
+						"path": {Type: "string"},
+						"from": {Type: "string"},
+						"value": {},
+					},
+				}},
+			}},
+			Response: models.MenuResponse{},
+			Errors: []int{400, 404, 409, 412, 415, 422, 428},
+		},
+		"DELETE /menu/:id": {
+			Summary: "Hapus menu",
+			Tag: "menu",
+			Params: []*openapi.Parameter{parameterRef("IfMatch")},
+			Response: models.MessageResponse{},
+			Errors: []int{400, 404, 412, 428},
+		},
+		"POST /webhooks": {
+			Summary: "Daftarkan webhook",
+			Description: "Event menu.created, menu.updated dan menu.deleted dikirim sebagai POST JSON dengan header " +
+				"X-Webhook-Signature (sha256=HMAC-SHA256(secret, \"<X-Webhook-Timestamp>.<body>\")). Secret kosong dibuatkan server " +
+				"dan hanya ditampilkan di response ini.",
+			Tag: "webhooks",
+			Body: models.CreateWebhookRequest{},
+			Response: models.WebhookCreatedResponse{},
+			Status: fiber.StatusCreated,
+			Errors: []int{400},
+		},
+		"GET /webhooks": {
+			Summary: "Daftar webhook",
+			Tag: "webhooks",
+			Response: models.WebhookListResponse{},
+		},
+		"GET /webhooks/deliveries": {
+			Summary: "Daftar pengiriman webhook (status=dead untuk dead-letter)",
+			Tag: "webhooks",
+			Params: append([]*openapi.Parameter{
+				query("webhook_id", "integer", "Batasi ke satu webhook"),
+			}, deliveryParams()...),
+			Response: models.WebhookDeliveryListResponse{},
+			Errors: []int{400, 404},
+		},
+		"GET /webhooks/deliveries/:id": {
+			Summary: "Detail pengiriman beserta log setiap percobaan",
+			Tag: "webhooks",
+			Response: models.WebhookDeliveryResponse{},
+			Errors: []int{400, 404},
+		},
+		"POST /webhooks/deliveries/:id/redeliver": {
+			Summary: "Kirim ulang pengiriman dengan jatah retry baru",
+			Tag: "webhooks",
+			Response: models.WebhookDeliveryResponse{},
+			Status: fiber.StatusAccepted,
+			Errors: []int{400, 404, 409},
+		},
+		"GET /webhooks/:id": {
+			Summary: "Detail webhook",
+			Tag: "webhooks",
+			Response: models.WebhookResponse{},
+			Errors: []int{400, 404},
+		},
+		"PUT /webhooks/:id": {
+			Summary: "Update URL, event, deskripsi & status aktif webhook",
+			Tag: "webhooks",
+			Body: models.UpdateWebhookRequest{},
+			Response: models.WebhookResponse{},
+			Errors: []int{400, 404},
+		},
+		"DELETE /webhooks/:id": {
+			Summary: "Hapus webhook beserta riwayat pengiriman",
+			Tag: "webhooks",
+			Response: models.MessageResponse{},
+			Errors: []int{400, 404},
+		},
+		"POST /webhooks/:id/ping": {
+			Summary: "Kirim event webhook.ping untuk menguji penerima",
+			Tag: "webhooks",
+			Response: models.WebhookDeliveryResponse{},
+			Status: fiber.StatusAccepted,
+			Errors: []int{400, 404, 409},
+		},
+		"GET /webhooks/:id/deliveries": {
+			Summary: "Daftar pengiriman satu webhook",
+			Tag: "webhooks",
+			Params: deliveryParams(),
+			Response: models.WebhookDeliveryListResponse{},
+			Errors: []int{400, 404},
+		},
+		"POST /graphql": {
+			Summary: "Query & mutation GraphQL katalog menu",
+			Description: "Schema: menu, menus, search, categories, recommendations dan mutation createMenu, updateMenu, deleteMenu. " +
+				"Error query dikirim di field errors dengan status 200, extensions berisi code seperti problem+json. " +
+				"recommendations butuh ai:use, createMenu & updateMenu butuh menu:write (ubah harga: price:write), deleteMenu butuh menu:delete.",
+			Tag: "graphql",
+			Body: models.GraphQLRequest{},
+			Response: models.GraphQLResponse{},
+			Errors: []int{400},
+		},
+		"POST /auth/token": {
+			Summary: "Tukar username & password dengan access token (JWT)",
+			Description: "Token dikirim di header Authorization: Bearer <access_token> sampai expires_in detik. Satu-satunya endpoint v1 tanpa autentikasi.",
+			Tag: "auth",
+			Body: models.TokenRequest{},
+			Response: models.TokenResponse{},
+			Errors: []int{400, 401, 503},
+		},
+		"GET /auth/me": {
+			Summary: "Principal (user atau API key) request ini",
+			Tag: "auth",
+			Response: models.PrincipalResponse{},
+		},
+		"POST /auth/api-keys": {
+			Summary: "Buat API key untuk client server-to-server",
+			Description: "Key dikirim di header X-API-Key (atau Authorization: Bearer) dan hanya ditampilkan di response ini, server hanya menyimpan hash-nya.",
+			Tag: "auth",
+			Body: models.CreateAPIKeyRequest{},
+			Response: models.APIKeyCreatedResponse{},
+			Status: fiber.StatusCreated,
+			Errors: []int{400},
+		},
+		"GET /auth/api-keys": {
+			Summary: "Daftar API key (tanpa key)",
+			Tag: "auth",
+			Response: models.APIKeyListResponse{},
+		},
+		"DELETE /auth/api-keys/:id": {
+			Summary: "Cabut API key",
+			Tag: "auth",
+			Response: models.MessageResponse{},
+			Errors: []int{400, 404},
+		},
+		"GET /auth/roles": {
+			Summary: "Role bawaan beserta permission-nya",
+			Description: "cashier: lihat katalog & rekomendasi, editor: + buat & ubah menu tanpa ubah harga, " +
+				"manager: + hapus menu, ubah harga & laporan, admin: + webhook, API key & role assignment.",
+			Tag: "auth",
+			Response: models.RoleListResponse{},
+		},
+		"GET /auth/role-assignments": {
+			Summary: "Daftar role assignment",
+			Tag: "auth",
+			Params: []*openapi.Parameter{
+				query("principal", "string", "Batasi ke satu principal, mis. user:3 atau api_key:7"),
+			},
+			Response: models.RoleAssignmentListResponse{},
+		},
+		"POST /auth/role-assignments": {
+			Summary: "Beri role ke user atau API key",
+			Description: "Principal berformat user:<id> atau api_key:<id> (id dari GET /auth/me atau GET /auth/api-keys). Berlaku mulai request berikutnya.",
+			Tag: "auth",
+			Body: models.CreateRoleAssignmentRequest{},
+			Response: models.RoleAssignmentResponse{},
+			Status: fiber.StatusCreated,
+			Errors: []int{400, 409},
+		},
+		"DELETE /auth/role-assignments/:id": {
+			Summary: "Cabut role assignment",
+			Description: "Role admin terakhir tidak bisa dicabut (409 last_admin).",
+			Tag: "auth",
+			Response: models.RoleAssignmentResponse{},
+			Errors: []int{400, 404, 409},
+		},
+		"GET /admin/search-report": {
+			Summary: "Laporan query pencarian per hari",
+			Tag: "admin",
+			Params: []*openapi.Parameter{
+				query("from", "string", "Tanggal awal YYYY-MM-DD (default 7 hari terakhir)"),
+				query("to", "string", "Tanggal akhir YYYY-MM-DD (default hari ini)"),
+				query("source", "string", "Sumber pencarian", models.SearchSourceSearch, models.SearchSourceSemantic, models.SearchSourceRecommendation),
+				query("limit", "integer", "Jumlah top query per hari (default 10, max 100)"),
+			},
+			Response: models.SearchReportResponse{},
+			Errors: []int{400},
+		},
+		"GET /admin/duplicates": {
+			Summary: "Pasangan menu dengan nama mirip",
+			Tag: "admin",
+			Params: []*openapi.Parameter{
+				query("category", "string", "Batasi ke satu kategori"),
+				query("threshold", "number", "Skor similarity minimal 0-1 (default 0.6)"),
+				query("cross_category", "boolean", "Bandingkan antar kategori"),
+				query("limit", "integer", "Jumlah pasangan (default 50, max 500)"),
+			},
+			Response: models.NearDuplicateReport{},
+			Errors: []int{400},
+		},
+	}
+}
+
+// filter list menu (dipakai list & export)
+func filterParams() []*openapi.Parameter{
+	return []*openapi.Parameter{
+		query("q", "string", "Cari di nama & deskripsi"),
+		query("category", "string", "Kategori menu", "foods", "drinks", "desserts", "snacks"),
+		query("min_price", "number", "Harga minimal"),
+		query("max_price", "number", "Harga maksimal"),
+		query("max_cal", "integer", "Kalori maksimal"),
+		query("filter", "string", "Ekspresi filter, contoh: category in (foods,snacks) and calories between 200 and 500"),
+		query("sort", "string", "Urutan, contoh: price:asc,name:desc"),
+	}
+}
+
+// filter & paging daftar pengiriman webhook
+func deliveryParams() []*openapi.Parameter{
+	return []*openapi.Parameter{
+		query("status", "string", "Status pengiriman", models.DeliveryPending, models.DeliverySucceeded, models.DeliveryDead),
+		query("page", "integer", "Halaman"),
+		query("per_page", "integer", "Jumlah per halaman (default 20, max 100)"),
+	}
+}
+
+// filter & resume feed perubahan katalog
+func feedParams() []*openapi.Parameter{
+	return []*openapi.Parameter{
+		query("category", "string", "Hanya event menu di kategori ini, dipisah koma (kosong = semua)"),
+		query("last_event_id", "string", "Lanjutkan setelah event ini, format <epoch>-<id> (untuk koneksi pertama / WebSocket)"),
+		header("Last-Event-ID", "Dikirim otomatis oleh EventSource saat reconnect"),
+		query("access_token", "string", "Access token / API key, untuk EventSource & WebSocket browser yang tidak bisa mengirim header Authorization"),
+	}
+}
+
+// paging offset & cursor, elemen pertama (sort) dilewati kalau sudah ada di filterParams
+func pageParams() []*openapi.Parameter{
+	return []*openapi.Parameter{
+		query("sort", "string", "Urutan, contoh: price:asc,name:desc"),
+		query("page", "integer", "Halaman (mode offset)"),
+		query("per_page", "integer", "Jumlah per halaman (max 100)"),
+		query("cursor", "string", "Cursor dari next_cursor/prev_cursor, kosong = halaman pertama"),
+		query("with_total", "boolean", "Hitung total data"),
+	}
+}
+
+// sparse fieldset & relasi
+func viewParams() []*openapi.Parameter{
+	return []*openapi.Parameter{
+		query("fields", "string", "Field yang dikirim dipisah koma, contoh: id,name,price"),
+		query("include", "string", "Relasi dipisah koma: variants, tags, images"),
+	}
+}
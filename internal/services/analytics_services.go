@@ -0,0 +1,145 @@
+package services
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+)
+
+const reportDateLayout = "2006-01-02"
+
+// pencatatan & laporan query pencarian
+type AnalyticsService struct {
+	repo *repositories.AnalyticsRepository
+	loc  *time.Location
+}
+
+func NewAnalyticsService(repo *repositories.AnalyticsRepository) *AnalyticsService {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		// tanpa tzdata: WIB tidak punya DST, nama tetap dipakai untuk AT TIME ZONE di db
+		loc = time.FixedZone("Asia/Jakarta", 7*60*60)
+	}
+	return &AnalyticsService{repo: repo, loc: loc}
+}
+
+// catat query secara async supaya tidak nambah latency request
+func (s *AnalyticsService) Record(source, query string, resultCount int, latency time.Duration) {
+	normalized := NormalizeQuery(query)
+	if normalized == "" {
+		return
+	}
+
+	entry := &models.SearchLog{
+		Source:          source,
+		Query:           query,
+		NormalizedQuery: normalized,
+		ResultCount:     resultCount,
+		LatencyMs:       latency.Milliseconds(),
+	}
+
+	go func() {
+		if err := s.repo.Create(entry); err != nil {
+			log.Printf("Gagal mencatat search log: %v", err)
+		}
+	}()
+}
+
+// laporan top query & query tanpa hasil per hari, rentang [from, to] inklusif
+func (s *AnalyticsService) Report(fromStr, toStr, source string, limit int) (*models.SearchReportResponse, error) {
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	today := time.Now().In(s.loc)
+	to, err := s.parseDate(toStr, today)
+	if err != nil {
+		return nil, err
+	}
+	from, err := s.parseDate(fromStr, to.AddDate(0, 0, -6))
+	if err != nil {
+		return nil, err
+	}
+	if from.After(to) {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgDateRange)
+	}
+	end := to.AddDate(0, 0, 1)
+
+	totals, err := s.repo.DailyTotals(from, end, source)
+	if err != nil {
+		return nil, err
+	}
+	top, err := s.repo.DailyQueryStats(from, end, source, false)
+	if err != nil {
+		return nil, err
+	}
+	zero, err := s.repo.DailyQueryStats(from, end, source, true)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]models.DailySearchReport, 0, len(totals))
+	byDate := make(map[string]int)
+	for _, t := range totals {
+		date := t.Day.Format(reportDateLayout)
+		byDate[date] = len(days)
+		days = append(days, models.DailySearchReport{
+			Date:              date,
+			TotalSearches:     t.Total,
+			ZeroResultCount:   t.Zero,
+			TopQueries:        []models.QueryStat{},
+			ZeroResultQueries: []models.QueryStat{},
+		})
+	}
+
+	// hasil repo sudah urut count DESC, ambil limit teratas per hari
+	for _, stat := range top {
+		if i, ok := byDate[stat.Day.Format(reportDateLayout)]; ok && len(days[i].TopQueries) < limit {
+			days[i].TopQueries = append(days[i].TopQueries, toQueryStat(stat))
+		}
+	}
+	for _, stat := range zero {
+		if i, ok := byDate[stat.Day.Format(reportDateLayout)]; ok && len(days[i].ZeroResultQueries) < limit {
+			days[i].ZeroResultQueries = append(days[i].ZeroResultQueries, toQueryStat(stat))
+		}
+	}
+
+	return &models.SearchReportResponse{
+		From:   from.Format(reportDateLayout),
+		To:     to.Format(reportDateLayout),
+		Source: source,
+		Days:   days,
+	}, nil
+}
+
+func (s *AnalyticsService) parseDate(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Date(fallback.Year(), fallback.Month(), fallback.Day(), 0, 0, 0, 0, s.loc), nil
+	}
+	t, err := time.ParseInLocation(reportDateLayout, value, s.loc)
+	if err != nil {
+		return time.Time{}, Invalid(CodeInvalidRequest, i18n.MsgDateFormat)
+	}
+	return t, nil
+}
+
+func toQueryStat(stat repositories.DailyQueryStat) models.QueryStat {
+	return models.QueryStat{
+		Query:        stat.Query,
+		Count:        stat.Count,
+		AvgResults:   stat.AvgResults,
+		AvgLatencyMs: stat.AvgLatencyMs,
+	}
+}
+
+// normalisasi query: lowercase, trim, spasi ganda jadi satu
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+const (
+	// password user lokal minimal
+	minPasswordLength = 8
+	// last_used_at API key hanya diupdate kalau sudah lewat selama ini, supaya tidak menulis ke db tiap request
+	apiKeyTouchInterval = time.Minute
+)
+
+// autentikasi: token JWT untuk user lokal & API key untuk client server-to-server
+type AuthService struct {
+	repo     *repositories.AuthRepository
+	tokens   *auth.TokenManager
+	validate *validator.Validate
+}
+
+func NewAuthService(repo *repositories.AuthRepository, tokens *auth.TokenManager) *AuthService {
+	return &AuthService{
+		repo:     repo,
+		tokens:   tokens,
+		validate: newValidator(),
+	}
+}
+
+// tukar username & password dengan access token (JWT)
+func (s *AuthService) IssueToken(req models.TokenRequest) (*models.TokenResponse, error) {
+	if err := s.validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.FindUserByUsername(req.Username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	hash := ""
+	if user != nil {
+		hash = user.PasswordHash
+	}
+	if !auth.CheckPassword(hash, req.Password) || !user.Active {
+		return nil, ErrInvalidCredentials
+	}
+
+	token, claims, err := s.tokens.Issue(strconv.FormatUint(uint64(user.ID), 10), user.Username, time.Now())
+	if errors.Is(err, auth.ErrCannotSign) {
+		return nil, ErrTokenUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(claims.ExpiresAt.Sub(claims.IssuedAt.Time).Seconds()),
+	}, nil
+}
+
+// principal dari bearer token. Subject angka = user lokal dan harus masih aktif,
+// subject lain dianggap dari issuer lain yang memakai kunci yang sama (RS256)
+func (s *AuthService) AuthenticateToken(token string) (*auth.Principal, error) {
+	claims, err := s.tokens.Verify(token, time.Now())
+	if errors.Is(err, auth.ErrTokenExpired) {
+		return nil, ErrTokenExpired
+	}
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	principal := &auth.Principal{Type: auth.PrincipalUser, Subject: claims.Subject, Name: claims.Name}
+	id, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		return s.loadRoles(principal)
+	}
+	user, err := s.repo.GetUser(uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !user.Active) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	principal.ID = user.ID
+	principal.Name = user.Username
+	return s.loadRoles(principal)
+}
+
+// principal dari API key, key yang kedaluwarsa atau dicabut ditolak
+func (s *AuthService) AuthenticateAPIKey(key string) (*auth.Principal, error) {
+	prefix, ok := auth.APIKeyPrefix(key)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+	stored, err := s.repo.FindAPIKeyByPrefix(prefix)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !auth.APIKeyMatches(key, stored.Hash) || stored.RevokedAt != nil || (stored.ExpiresAt != nil && !now.Before(*stored.ExpiresAt)) {
+		return nil, ErrInvalidAPIKey
+	}
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) > apiKeyTouchInterval {
+		if err := s.repo.TouchAPIKey(stored.ID, now); err != nil {
+			log.Printf("Gagal update last_used_at API key %d: %v", stored.ID, err)
+		}
+	}
+	return s.loadRoles(&auth.Principal{
+		Type:    auth.PrincipalAPIKey,
+		ID:      stored.ID,
+		Subject: stored.Prefix,
+		Name:    stored.Name,
+	})
+}
+
+// buat API key, key lengkap hanya dikembalikan di sini
+func (s *AuthService) CreateAPIKey(req models.CreateAPIKeyRequest, createdBy *auth.Principal) (*models.APIKey, string, error) {
+	if err := s.validateStruct(req); err != nil {
+		return nil, "", err
+	}
+
+	key, prefix, hash := auth.NewAPIKey()
+	apiKey := &models.APIKey{
+		Name:   req.Name,
+		Prefix: prefix,
+		Hash:   hash,
+	}
+	if createdBy != nil {
+		apiKey.CreatedBy = createdBy.Key()
+	}
+	if req.ExpiresInDays > 0 {
+		expires := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		apiKey.ExpiresAt = &expires
+	}
+	if err := s.repo.CreateAPIKey(apiKey); err != nil {
+		return nil, "", err
+	}
+	return apiKey, key, nil
+}
+
+func (s *AuthService) ListAPIKeys() ([]models.APIKey, error) {
+	return s.repo.ListAPIKeys()
+}
+
+func (s *AuthService) RevokeAPIKey(id uint) (*models.APIKey, error) {
+	key, err := s.repo.RevokeAPIKey(id, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// buat user lokal atau ganti password-nya (CLI & bootstrap)
+func (s *AuthService) SaveUser(username, password string) (*models.User, error) {
+	if username == "" {
+		return nil, errors.New("username wajib diisi")
+	}
+	if len(password) < minPasswordLength {
+		return nil, fmt.Errorf("password minimal %d karakter", minPasswordLength)
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{Username: username, PasswordHash: hash, Active: true}
+	if err := s.repo.SaveUser(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// buat user pertama dengan role admin kalau belum ada user sama sekali, supaya
+// server baru bisa langsung dipakai. Kalau user sudah ada tapi belum ada admin
+// (upgrade dari versi tanpa role), user bootstrap dijadikan admin
+func (s *AuthService) Bootstrap(username, password string) error {
+	if username == "" || password == "" {
+		return nil
+	}
+	count, err := s.repo.CountUsers()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		user, err := s.SaveUser(username, password)
+		if err != nil {
+			return err
+		}
+		if err := s.GrantRoles(user, []string{auth.RoleAdmin}); err != nil {
+			return err
+		}
+		log.Printf("User awal '%s' (admin) dibuat", username)
+		return nil
+	}
+
+	admins, err := s.repo.CountRole(auth.RoleAdmin)
+	if err != nil || admins > 0 {
+		return err
+	}
+	user, err := s.repo.FindUserByUsername(username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("Belum ada admin dan user '%s' tidak ditemukan, beri role lewat: go run ./cmd user --role admin", username)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.GrantRoles(user, []string{auth.RoleAdmin}); err != nil {
+		return err
+	}
+	log.Printf("Belum ada admin, user '%s' diberi role admin", username)
+	return nil
+}
+
+// beri role ke user lokal (CLI & bootstrap), role yang sudah dimiliki dilewati
+func (s *AuthService) GrantRoles(user *models.User, roles []string) error {
+	principal := (&auth.Principal{Type: auth.PrincipalUser, ID: user.ID}).Key()
+	for _, role := range roles {
+		_, err := s.AssignRole(models.CreateRoleAssignmentRequest{Principal: principal, Role: strings.TrimSpace(role)}, nil)
+		if err != nil && !errors.Is(err, ErrDuplicateRole) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AuthService) validateStruct(req interface{}) error {
+	if err := s.validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+	return nil
+}
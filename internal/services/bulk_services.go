@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+)
+
+const MaxBulkOperations = 1000
+
+// operasi bulk yang sudah di-decode & divalidasi
+type preparedOp struct {
+	op     models.BulkOperation
+	create *models.CreateMenuRequest
+	update *models.UpdateMenuRequest
+	err    error
+}
+
+// hasil eksekusi satu operasi, dipakai untuk efek samping setelah commit
+type executedOp struct {
+	op   string
+	menu *models.Menu
+}
+
+// operasi berikutnya tidak dijalankan karena ada yang gagal (mode atomic)
+var errBulkAborted = errors.New("transaksi bulk dibatalkan")
+
+// jalankan banyak operasi menu sekaligus. Mode atomic: semua dalam satu transaksi,
+// satu gagal = semua batal. Mode best_effort: tiap operasi berdiri sendiri.
+// Operasi delete & perubahan harga dicek terhadap permission principal di ctx
+func (s *MenuService) BulkApply(ctx context.Context, req models.BulkRequest) (*models.BulkResponse, error) {
+	if req.Mode == "" {
+		req.Mode = models.BulkModeAtomic
+	}
+	if req.Mode != models.BulkModeAtomic && req.Mode != models.BulkModeBestEffort {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgBulkMode, models.BulkModeAtomic, models.BulkModeBestEffort)
+	}
+	if len(req.Operations) == 0 {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgBulkEmpty)
+	}
+	if len(req.Operations) > MaxBulkOperations {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgBulkTooMany, MaxBulkOperations)
+	}
+
+	prepared := make([]preparedOp, len(req.Operations))
+	for i, op := range req.Operations {
+		prepared[i] = s.prepareBulkOp(ctx, op)
+	}
+
+	response := &models.BulkResponse{
+		Mode:    req.Mode,
+		Results: make([]models.BulkItemResult, len(prepared)),
+	}
+
+	if req.Mode == models.BulkModeAtomic {
+		s.bulkAtomic(ctx, prepared, response)
+	} else {
+		s.bulkBestEffort(ctx, prepared, response)
+	}
+
+	for _, r := range response.Results {
+		if r.Status == models.BulkStatusCreated || r.Status == models.BulkStatusUpdated || r.Status == models.BulkStatusDeleted {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+	return response, nil
+}
+
+func (s *MenuService) bulkAtomic(ctx context.Context, prepared []preparedOp, response *models.BulkResponse) {
+	// validasi dulu semua item sebelum menyentuh db
+	invalid := false
+	for i, p := range prepared {
+		if p.err != nil {
+			response.Results[i] = bulkFailure(i, p.op, p.err)
+			invalid = true
+		}
+	}
+	if invalid {
+		for i, p := range prepared {
+			if p.err == nil {
+				response.Results[i] = bulkSkipped(i, p.op)
+			}
+		}
+		return
+	}
+
+	executed := make([]executedOp, 0, len(prepared))
+	failedAt := -1
+	err := s.repo.Transaction(func(tx *repositories.MenuRepository) error {
+		for i, p := range prepared {
+			result, done, err := s.execBulkOp(ctx, tx, i, p)
+			response.Results[i] = result
+			if err != nil {
+				failedAt = i
+				return errBulkAborted
+			}
+			executed = append(executed, done)
+		}
+		return nil
+	})
+
+	if err != nil {
+		// error saat commit (bukan dari operasi) dianggap gagal di semua item
+		for i, p := range prepared {
+			switch {
+			case failedAt < 0:
+				response.Results[i] = bulkFailure(i, p.op, err)
+			case i < failedAt:
+				// id create yang dibatalkan tidak pernah tersimpan, tidak dikirim
+				id := p.op.ID
+				if p.op.Op == models.BulkOpCreate {
+					id = 0
+				}
+				response.Results[i] = models.BulkItemResult{
+					Index:  i,
+					Op:     p.op.Op,
+					ID:     id,
+					Status: models.BulkStatusRolledBack,
+					Code:   http.StatusFailedDependency,
+					Error:  i18n.T(i18n.Default(), i18n.MsgBulkRolledBack),
+				}
+			case i > failedAt:
+				response.Results[i] = bulkSkipped(i, p.op)
+			}
+		}
+		return
+	}
+
+	for _, done := range executed {
+		s.afterBulkOp(done)
+	}
+}
+
+func (s *MenuService) bulkBestEffort(ctx context.Context, prepared []preparedOp, response *models.BulkResponse) {
+	for i, p := range prepared {
+		if p.err != nil {
+			response.Results[i] = bulkFailure(i, p.op, p.err)
+			continue
+		}
+
+		// tiap operasi satu transaksi sendiri bersama event outbox-nya
+		var done executedOp
+		err := s.repo.Transaction(func(tx *repositories.MenuRepository) error {
+			result, executed, err := s.execBulkOp(ctx, tx, i, p)
+			response.Results[i], done = result, executed
+			return err
+		})
+		if err == nil {
+			s.afterBulkOp(done)
+		}
+	}
+}
+
+// decode data & validasi struct tanpa akses db
+func (s *MenuService) prepareBulkOp(ctx context.Context, op models.BulkOperation) preparedOp {
+	p := preparedOp{op: op}
+
+	switch op.Op {
+	case models.BulkOpCreate:
+		var req models.CreateMenuRequest
+		if p.err = decodeBulkData(op.Data, &req); p.err == nil {
+			p.err = s.validateStruct(req)
+		}
+		p.create = &req
+
+	case models.BulkOpUpdate:
+		if op.ID == 0 {
+			p.err = Invalid(CodeValidation, i18n.MsgBulkIDRequired, models.BulkOpUpdate)
+			break
+		}
+		var req models.UpdateMenuRequest
+		if p.err = decodeBulkData(op.Data, &req); p.err == nil {
+			p.err = s.validateStruct(req)
+		}
+		p.update = &req
+
+	case models.BulkOpDelete:
+		if op.ID == 0 {
+			p.err = Invalid(CodeValidation, i18n.MsgBulkIDRequired, models.BulkOpDelete)
+			break
+		}
+		p.err = Authorize(ctx, auth.PermMenuDelete)
+
+	default:
+		p.err = Invalid(CodeValidation, i18n.MsgBulkUnknownOp, op.Op)
+	}
+	return p
+}
+
+func decodeBulkData(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 {
+		return Invalid(CodeValidation, i18n.MsgBulkDataRequired)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return Invalid(CodeInvalidBody, i18n.MsgBulkDataInvalid, err)
+	}
+	return nil
+}
+
+func (s *MenuService) execBulkOp(ctx context.Context, repo *repositories.MenuRepository, index int, p preparedOp) (models.BulkItemResult, executedOp, error) {
+	var (
+		menu   *models.Menu
+		err    error
+		status string
+		code   int
+		typ    events.Type
+	)
+
+	switch p.op.Op {
+	case models.BulkOpCreate:
+		menu, err = s.createMenu(repo, *p.create)
+		status, code, typ = models.BulkStatusCreated, http.StatusCreated, events.MenuCreated
+	case models.BulkOpUpdate:
+		menu, err = s.updateMenu(ctx, repo, p.op.ID, *p.update, p.op.Version)
+		status, code, typ = models.BulkStatusUpdated, http.StatusOK, events.MenuUpdated
+	case models.BulkOpDelete:
+		menu, err = s.deleteMenu(repo, p.op.ID, p.op.Version)
+		status, code, typ = models.BulkStatusDeleted, http.StatusOK, events.MenuDeleted
+	}
+	if err == nil {
+		err = s.recordEvent(repo, typ, menu)
+	}
+
+	if err != nil {
+		return bulkFailure(index, p.op, err), executedOp{}, err
+	}
+
+	result := models.BulkItemResult{Index: index, Op: p.op.Op, ID: menu.ID, Status: status, Code: code}
+	if p.op.Op != models.BulkOpDelete {
+		result.Data = menu
+	}
+	return result, executedOp{op: p.op.Op, menu: menu}, nil
+}
+
+func (s *MenuService) afterBulkOp(done executedOp) {
+	if done.op == models.BulkOpDelete {
+		s.onDeleted(done.menu)
+		return
+	}
+	s.onSaved(done.menu)
+}
+
+func bulkFailure(index int, op models.BulkOperation, err error) models.BulkItemResult {
+	domain := Classify(err)
+	return models.BulkItemResult{
+		Index:     index,
+		Op:        op.Op,
+		ID:        op.ID,
+		Status:    models.BulkStatusFailed,
+		Code:      domain.Status(),
+		Error:     itemError(err),
+		ErrorCode: domain.Code,
+		Fields:    domain.Fields,
+	}
+}
+
+func bulkSkipped(index int, op models.BulkOperation) models.BulkItemResult {
+	return models.BulkItemResult{
+		Index:  index,
+		Op:     op.Op,
+		ID:     op.ID,
+		Status: models.BulkStatusSkipped,
+		Code:   http.StatusFailedDependency,
+		Error:  i18n.T(i18n.Default(), i18n.MsgBulkSkipped),
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+)
+
+// batas menu yang dibandingkan (perbandingan berpasangan, O(n²))
+const maxDuplicateScan = 5000
+
+// laporan menu dengan nama mirip (similarity trigram, mirip pg_trgm).
+// Default hanya dibandingkan dalam kategori yang sama
+func (s *MenuService) NearDuplicates(category string, threshold float64, crossCategory bool, limit int) (*models.NearDuplicateReport, error) {
+	if threshold <= 0 {
+		threshold = 0.6
+	}
+	if threshold > 1 {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgThresholdRange)
+	}
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	menus, err := s.repo.ListNames(category)
+	if err != nil {
+		return nil, err
+	}
+	if len(menus) > maxDuplicateScan {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgTooManyMenus, len(menus))
+	}
+
+	grams := make([]map[string]bool, len(menus))
+	for i, m := range menus {
+		grams[i] = trigrams(models.NormalizeMenuName(m.Name))
+	}
+
+	pairs := []models.NearDuplicatePair{}
+	for i := range menus {
+		for j := i + 1; j < len(menus); j++ {
+			if !crossCategory && menus[i].Category != menus[j].Category {
+				continue
+			}
+			score := trigramSimilarity(grams[i], grams[j])
+			if score < threshold {
+				continue
+			}
+			pairs = append(pairs, models.NearDuplicatePair{
+				A:          duplicateRef(menus[i]),
+				B:          duplicateRef(menus[j]),
+				Similarity: float64(int(score*1000+0.5)) / 1000,
+			})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Similarity > pairs[j].Similarity
+	})
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+
+	return &models.NearDuplicateReport{
+		Threshold:     threshold,
+		CrossCategory: crossCategory,
+		Scanned:       len(menus),
+		Pairs:         pairs,
+	}, nil
+}
+
+func duplicateRef(m models.Menu) models.DuplicateMenuRef {
+	return models.DuplicateMenuRef{ID: m.ID, Name: m.Name, Category: m.Category}
+}
+
+// trigram per kata, diberi padding "  kata " seperti pg_trgm
+func trigrams(name string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(name) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// |A ∩ B| / |A ∪ B|
+func trigramSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for g := range a {
+		if b[g] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
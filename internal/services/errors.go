@@ -0,0 +1,358 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"GDGOC-API/internal/filterexpr"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/openapi"
+	"GDGOC-API/internal/patch"
+	"GDGOC-API/internal/repositories"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// jenis error, menentukan status HTTP
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalid
+	KindUnauthorized
+	KindForbidden
+	KindValidation
+	KindUnprocessable
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+	KindPreconditionRequired
+	KindUnsupportedMediaType
+	KindUnavailable
+)
+
+var kindStatus = map[ErrorKind]int{
+	KindInternal:             http.StatusInternalServerError,
+	KindInvalid:              http.StatusBadRequest,
+	KindUnauthorized:         http.StatusUnauthorized,
+	KindForbidden:            http.StatusForbidden,
+	KindValidation:           http.StatusBadRequest,
+	KindUnprocessable:        http.StatusUnprocessableEntity,
+	KindNotFound:             http.StatusNotFound,
+	KindConflict:             http.StatusConflict,
+	KindPreconditionFailed:   http.StatusPreconditionFailed,
+	KindPreconditionRequired: http.StatusPreconditionRequired,
+	KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	KindUnavailable:          http.StatusServiceUnavailable,
+}
+
+// kode error stabil (bagian dari kontrak API, jangan diganti)
+const (
+	CodeInternal             = "internal_error"
+	CodeInvalidRequest       = "invalid_request"
+	CodeInvalidBody          = "invalid_body"
+	CodeInvalidID            = "invalid_id"
+	CodeValidation           = "validation_failed"
+	CodeMenuNotFound         = "menu_not_found"
+	CodeNotFound             = "not_found"
+	CodeDuplicateMenu        = "duplicate_menu"
+	CodeVersionMismatch      = "version_mismatch"
+	CodeIfMatchRequired      = "if_match_required"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeInvalidPatch         = "invalid_patch"
+	CodePatchTestFailed      = "patch_test_failed"
+	CodeInvalidFilter        = "invalid_filter"
+	CodeInvalidSort          = "invalid_sort"
+	CodeInvalidCursor        = "invalid_cursor"
+	CodeInvalidFields        = "invalid_fields"
+	CodeInvalidInclude       = "invalid_include"
+	CodeIdempotencyMismatch  = "idempotency_key_mismatch"
+	CodeIdempotencyConflict  = "idempotency_key_in_progress"
+	CodeSemanticUnavailable  = "semantic_search_unavailable"
+	CodeQueryTooDeep         = "query_too_deep"
+	CodeQueryTooComplex      = "query_too_complex"
+	CodeWebhookNotFound      = "webhook_not_found"
+	CodeDeliveryNotFound     = "webhook_delivery_not_found"
+	CodeWebhookInactive      = "webhook_inactive"
+	CodeUnauthorized         = "unauthorized"
+	CodeInvalidToken         = "invalid_token"
+	CodeInvalidCredentials   = "invalid_credentials"
+	CodeAPIKeyNotFound       = "api_key_not_found"
+	CodeTokenUnavailable     = "token_issue_unavailable"
+	CodeForbidden            = "forbidden"
+	CodeUnknownRole          = "unknown_role"
+	CodeInvalidPrincipal     = "invalid_principal"
+	CodeDuplicateRole        = "role_already_assigned"
+	CodeRoleNotFound         = "role_assignment_not_found"
+	CodeLastAdmin            = "last_admin"
+)
+
+// error domain dari service, dipetakan ke problem+json oleh error handler.
+// Pesan diambil dari katalog i18n (key + args) supaya bisa diterjemahkan per request,
+// Message hanya dipakai untuk pesan dari luar katalog
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Fields  []models.FieldError
+	Err     error
+
+	key  string
+	args []interface{}
+}
+
+func (e *Error) Error() string {
+	return e.message(i18n.Default())
+}
+
+func (e *Error) message(lang string) string {
+	if e.key == "" {
+		return e.Message
+	}
+	return i18n.T(lang, e.key, e.args...)
+}
+
+// salinan error dengan pesan & error per field dalam bahasa lang
+func (e *Error) Localize(lang string) *Error {
+	localized := *e
+	localized.Message = e.message(lang)
+
+	var verrs validator.ValidationErrors
+	var schemaErr *openapi.ValidationError
+	switch {
+	case errors.As(e.Err, &verrs):
+		localized.Fields = fieldErrors(verrs, lang)
+	case errors.As(e.Err, &schemaErr):
+		localized.Fields = violationErrors(schemaErr.Violations, lang)
+	}
+	return &localized
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// errors.Is cocok kalau kodenya sama, jadi sentinel tetap cocok walau dibungkus ulang
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) Status() int {
+	if status, ok := kindStatus[e.Kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+func newError(kind ErrorKind, code, key string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Code: code, key: key, args: args}
+}
+
+// request tidak valid (400) dengan kode tertentu, key = key pesan di katalog i18n
+func Invalid(code, key string, args ...interface{}) *Error {
+	return newError(KindInvalid, code, key, args...)
+}
+
+// error dengan kind & kode tertentu, key = key pesan di katalog i18n
+func Errorf(kind ErrorKind, code, key string, args ...interface{}) *Error {
+	return newError(kind, code, key, args...)
+}
+
+var (
+	ErrMenuNotFound          = newError(KindNotFound, CodeMenuNotFound, i18n.MsgMenuNotFound)
+	ErrSemanticUnavailable   = newError(KindUnavailable, CodeSemanticUnavailable, i18n.MsgSemanticOff)
+	ErrIdempotencyMismatch   = newError(KindUnprocessable, CodeIdempotencyMismatch, i18n.MsgIdemMismatch)
+	ErrIdempotencyInProgress = newError(KindConflict, CodeIdempotencyConflict, i18n.MsgIdemInProgress)
+	// versi menu tidak sama dengan yang diharapkan client (If-Match)
+	ErrPreconditionFailed = newError(KindPreconditionFailed, CodeVersionMismatch, i18n.MsgVersionMismatch)
+	ErrWebhookNotFound    = newError(KindNotFound, CodeWebhookNotFound, i18n.MsgWebhookNotFound)
+	ErrDeliveryNotFound   = newError(KindNotFound, CodeDeliveryNotFound, i18n.MsgDeliveryNotFound)
+	ErrWebhookInactive    = newError(KindConflict, CodeWebhookInactive, i18n.MsgWebhookInactive)
+	// request tanpa kredensial ke endpoint yang butuh autentikasi
+	ErrUnauthorized       = newError(KindUnauthorized, CodeUnauthorized, i18n.MsgUnauthorized)
+	ErrInvalidToken       = newError(KindUnauthorized, CodeInvalidToken, i18n.MsgInvalidToken)
+	ErrTokenExpired       = newError(KindUnauthorized, CodeInvalidToken, i18n.MsgTokenExpired)
+	ErrInvalidAPIKey      = newError(KindUnauthorized, CodeInvalidToken, i18n.MsgInvalidAPIKey)
+	ErrInvalidCredentials = newError(KindUnauthorized, CodeInvalidCredentials, i18n.MsgInvalidCredentials)
+	ErrTokenUnavailable   = newError(KindUnavailable, CodeTokenUnavailable, i18n.MsgTokenIssueUnavailable)
+	ErrAPIKeyNotFound     = newError(KindNotFound, CodeAPIKeyNotFound, i18n.MsgAPIKeyNotFound)
+	ErrInvalidPrincipal   = newError(KindInvalid, CodeInvalidPrincipal, i18n.MsgInvalidPrincipal)
+	ErrRoleNotFound       = newError(KindNotFound, CodeRoleNotFound, i18n.MsgRoleAssignmentNotFound)
+	ErrLastAdmin          = newError(KindConflict, CodeLastAdmin, i18n.MsgLastAdmin)
+	ErrDuplicateRole      = newError(KindConflict, CodeDuplicateRole, i18n.MsgDuplicateRole)
+)
+
+// error validasi struct dengan detail per field (nama field mengikuti tag json)
+func validationError(err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	validation := newError(KindValidation, CodeValidation, i18n.MsgValidationFailed)
+	validation.Fields = fieldErrors(verrs, i18n.Default())
+	validation.Err = err
+	return validation
+}
+
+// pesan per field diterjemahkan lewat universal-translator
+func fieldErrors(verrs validator.ValidationErrors, lang string) []models.FieldError {
+	trans := i18n.Translator(lang)
+	fields := make([]models.FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fields = append(fields, models.FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fe.Translate(trans),
+		})
+	}
+	return fields
+}
+
+// error validasi kontrak OpenAPI (query, path, header, body)
+func schemaValidationError(err *openapi.ValidationError) *Error {
+	validation := newError(KindValidation, CodeValidation, i18n.MsgValidationFailed)
+	validation.Fields = violationErrors(err.Violations, i18n.Default())
+	validation.Err = err
+	return validation
+}
+
+func violationErrors(violations []openapi.Violation, lang string) []models.FieldError {
+	fields := make([]models.FieldError, 0, len(violations))
+	for _, v := range violations {
+		field := v.Field
+		if field == "" {
+			field = v.In
+		}
+		fields = append(fields, models.FieldError{
+			Field:   field,
+			In:      v.In,
+			Rule:    v.Rule,
+			Param:   v.Param,
+			Message: violationMessage(v, field, lang),
+		})
+	}
+	return fields
+}
+
+// pesan untuk rule, min/max dibedakan antara panjang string & jumlah item
+func violationMessage(v openapi.Violation, field, lang string) string {
+	if v.Rule == "required" {
+		return i18n.T(lang, i18n.MsgFieldRequired, field)
+	}
+	return i18n.T(lang, violationKey(v), field, v.Param)
+}
+
+func violationKey(v openapi.Violation) string {
+	switch v.Rule {
+	case "type":
+		return i18n.MsgSchemaType
+	case "oneof":
+		return i18n.MsgSchemaOneOf
+	case "gt":
+		return i18n.MsgSchemaGT
+	case "gte":
+		return i18n.MsgSchemaGTE
+	case "lt":
+		return i18n.MsgSchemaLT
+	case "lte":
+		return i18n.MsgSchemaLTE
+	case "min":
+		if v.Type == "array" {
+			return i18n.MsgSchemaMinItems
+		}
+		return i18n.MsgSchemaMinLen
+	case "max":
+		if v.Type == "array" {
+			return i18n.MsgSchemaMaxItems
+		}
+		return i18n.MsgSchemaMaxLen
+	}
+	return i18n.MsgSchemaType
+}
+
+// validator dengan nama field dari tag json & pesan terjemahan
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+	if err := i18n.RegisterValidator(validate); err != nil {
+		log.Printf("Gagal mendaftarkan terjemahan validator: %v", err)
+	}
+	return validate
+}
+
+// validasi struct request, error dikembalikan sebagai *Error dengan detail per field
+func (s *MenuService) validateStruct(req interface{}) error {
+	if err := s.validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+	return nil
+}
+
+// pakai nama di tag json sebagai nama field error
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// ubah error apa pun jadi *Error: error domain dikembalikan apa adanya, error
+// dari package lain (repository, patch, filter, gorm) diberi kind & kode
+func Classify(err error) *Error {
+	var domain *Error
+	if errors.As(err, &domain) {
+		return domain
+	}
+
+	var duplicate *DuplicateMenuError
+	if errors.As(err, &duplicate) {
+		dup := newError(KindConflict, CodeDuplicateMenu, i18n.MsgDuplicateMenu, duplicate.Name, duplicate.ExistingID)
+		dup.Err = err
+		return dup
+	}
+
+	var permission *PermissionError
+	if errors.As(err, &permission) {
+		forbidden := newError(KindForbidden, CodeForbidden, i18n.MsgForbidden, permission.Permission)
+		forbidden.Err = err
+		return forbidden
+	}
+
+	var filterErr *filterexpr.Error
+	var verrs validator.ValidationErrors
+	var schemaErr *openapi.ValidationError
+	switch {
+	case errors.As(err, &verrs):
+		return validationError(err).(*Error)
+	case errors.As(err, &schemaErr):
+		return schemaValidationError(schemaErr)
+	case errors.As(err, &filterErr):
+		return &Error{Kind: KindInvalid, Code: CodeInvalidFilter, Message: filterErr.Error(), Err: err}
+	case errors.Is(err, repositories.ErrInvalidSort):
+		return &Error{Kind: KindInvalid, Code: CodeInvalidSort, Message: err.Error(), Err: err}
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		return &Error{Kind: KindInvalid, Code: CodeInvalidCursor, Message: err.Error(), Err: err}
+	case errors.Is(err, repositories.ErrInvalidFields):
+		return &Error{Kind: KindInvalid, Code: CodeInvalidFields, Message: err.Error(), Err: err}
+	case errors.Is(err, repositories.ErrInvalidInclude):
+		return &Error{Kind: KindInvalid, Code: CodeInvalidInclude, Message: err.Error(), Err: err}
+	case errors.Is(err, patch.ErrTestFailed):
+		return &Error{Kind: KindConflict, Code: CodePatchTestFailed, Message: err.Error(), Err: err}
+	case errors.Is(err, patch.ErrInvalidPatch):
+		return &Error{Kind: KindInvalid, Code: CodeInvalidPatch, Message: err.Error(), Err: err}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		notFound := newError(KindNotFound, CodeNotFound, i18n.MsgNotFound)
+		notFound.Err = err
+		return notFound
+	}
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: err.Error(), Err: err}
+}
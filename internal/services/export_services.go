@@ -0,0 +1,46 @@
+package services
+
+import (
+	"io"
+
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/catalog"
+	"GDGOC-API/internal/models"
+)
+
+// export yang sudah divalidasi, data baru dibaca dari db saat WriteTo
+type MenuExport struct {
+	Format  string
+	Columns []string
+	each    func(fn func(*models.Menu) error) error
+}
+
+// siapkan export semua menu hasil filter (tanpa paging). Error filter, sort,
+// format & kolom dikembalikan di sini supaya bisa dijawab sebelum streaming
+func (s *MenuService) ExportMenus(filters models.MenuFilters, format string, columns string) (*MenuExport, error) {
+	if format != catalog.FormatCSV && format != catalog.FormatJSONL && format != catalog.FormatXLSX {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgExportFormat)
+	}
+	cols, err := catalog.ParseColumns(columns)
+	if err != nil {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgInvalidColumns, err)
+	}
+
+	each, err := s.repo.StreamAll(filters)
+	if err != nil {
+		return nil, err
+	}
+	return &MenuExport{Format: format, Columns: cols, each: each}, nil
+}
+
+// tulis seluruh baris ke w, satu menu per iterasi
+func (e *MenuExport) Stream(w io.Writer) error {
+	writer, err := catalog.NewWriter(w, e.Format, e.Columns)
+	if err != nil {
+		return err
+	}
+	if err := e.each(writer.Write); err != nil {
+		return err
+	}
+	return writer.Close()
+}
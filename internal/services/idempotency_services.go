@@ -0,0 +1,120 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+const MaxIdempotencyKeyLength = 255
+
+// simpan & replay response pertama per Idempotency-Key
+type IdempotencyService struct {
+	repo  *repositories.IdempotencyRepository
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// ttl = masa simpan response, lease = lama key dikunci selama request diproses
+func NewIdempotencyService(repo *repositories.IdempotencyRepository, ttl, lease time.Duration) *IdempotencyService {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	if lease <= 0 {
+		lease = time.Minute
+	}
+	return &IdempotencyService{repo: repo, ttl: ttl, lease: lease}
+}
+
+// sidik jari request: method, path (+query) dan body
+func IdempotencyFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// klaim key untuk request ini. Return record lama kalau response sudah tersimpan
+// (harus di-replay), nil kalau key baru diklaim dan request boleh diproses
+func (s *IdempotencyService) Begin(key, method, path string, body []byte) (*models.IdempotencyRecord, error) {
+	fingerprint := IdempotencyFingerprint(method, path, body)
+
+	// dua kali: kalau kalah balapan Reserve, baca ulang record pemenangnya
+	for attempt := 0; attempt < 2; attempt++ {
+		existing, err := s.repo.Get(key)
+		if err == nil {
+			if existing.Fingerprint != fingerprint {
+				return nil, ErrIdempotencyMismatch
+			}
+			if existing.StatusCode != 0 {
+				return existing, nil
+			}
+			// lease habis = request pertama tidak pernah selesai, key diklaim ulang
+			if existing.LockedUntil.After(time.Now()) {
+				return nil, ErrIdempotencyInProgress
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		now := time.Now()
+		reserved, err := s.repo.Reserve(&models.IdempotencyRecord{
+			Key:         key,
+			Method:      method,
+			Path:        path,
+			Fingerprint: fingerprint,
+			LockedUntil: now.Add(s.lease),
+			ExpiresAt:   now.Add(s.ttl),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+	}
+	return nil, ErrIdempotencyInProgress
+}
+
+// simpan response final untuk di-replay
+func (s *IdempotencyService) Complete(key string, status int, contentType, etag string, body []byte) {
+	err := s.repo.Complete(&models.IdempotencyRecord{
+		Key:         key,
+		StatusCode:  status,
+		ContentType: contentType,
+		ETag:        etag,
+		Body:        body,
+	})
+	if err != nil {
+		log.Printf("Gagal menyimpan response idempotency %s: %v", key, err)
+	}
+}
+
+// lepas key supaya request bisa dicoba ulang (misal server error)
+func (s *IdempotencyService) Release(key string) {
+	if err := s.repo.Delete(key); err != nil {
+		log.Printf("Gagal melepas idempotency key %s: %v", key, err)
+	}
+}
+
+// hapus record kadaluarsa secara berkala
+func (s *IdempotencyService) StartCleanup(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if n, err := s.repo.DeleteExpired(); err != nil {
+				log.Printf("Gagal membersihkan idempotency key: %v", err)
+			} else if n > 0 {
+				log.Printf("%d idempotency key kadaluarsa dihapus", n)
+			}
+		}
+	}()
+}
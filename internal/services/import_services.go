@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/catalog"
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// import katalog menu dari CSV/XLSX/JSONL. Tiap baris berdiri sendiri: baris gagal
+// dilaporkan tanpa membatalkan baris lain. DryRun hanya menghitung aksi.
+// Baris yang mengubah harga gagal kalau principal di ctx tidak punya price:write
+func (s *MenuService) ImportMenus(ctx context.Context, r io.Reader, opts models.ImportOptions) (*models.ImportReport, error) {
+	if opts.UpsertBy == "" {
+		opts.UpsertBy = models.ImportUpsertByName
+	}
+	if opts.UpsertBy != models.ImportUpsertByName && opts.UpsertBy != models.ImportUpsertByExternalID {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgImportUpsertBy, models.ImportUpsertByName, models.ImportUpsertByExternalID)
+	}
+	if opts.Format != catalog.FormatCSV && opts.Format != catalog.FormatXLSX && opts.Format != catalog.FormatJSONL {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgImportFormat)
+	}
+
+	rows, err := catalog.ReadRows(r, opts.Format)
+	if err != nil {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgImportFile, err)
+	}
+	parsed, err := catalog.ParseImportRows(rows)
+	if err != nil {
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgImportFile, err)
+	}
+
+	report := &models.ImportReport{
+		DryRun:   opts.DryRun,
+		UpsertBy: opts.UpsertBy,
+		Total:    len(parsed),
+		Rows:     make([]models.ImportRowResult, 0, len(parsed)),
+	}
+
+	// key upsert yang sudah muncul di file, baris duplikat ditolak
+	seen := make(map[string]int)
+	for _, row := range parsed {
+		result := s.importRow(ctx, row, opts, seen)
+		switch result.Action {
+		case models.ImportActionCreate:
+			report.Created++
+		case models.ImportActionUpdate:
+			report.Updated++
+		case models.ImportActionSkip:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	return report, nil
+}
+
+func (s *MenuService) importRow(ctx context.Context, row catalog.ImportRow, opts models.ImportOptions, seen map[string]int) models.ImportRowResult {
+	result := models.ImportRowResult{Line: row.Line, Name: row.Request.Name}
+	fail := func(errs ...string) models.ImportRowResult {
+		result.Action = models.ImportActionFailed
+		result.Errors = append(result.Errors, errs...)
+		return result
+	}
+
+	errs := append([]string{}, row.Errors...)
+	if err := s.validateStruct(row.Request); err != nil {
+		errs = append(errs, validationMessages(err)...)
+	}
+
+	key := importNameKey(row.Request.Name, row.Request.Category, s.uniqueName)
+	if opts.UpsertBy == models.ImportUpsertByExternalID {
+		key = row.ExternalID
+		if key == "" {
+			errs = append(errs, i18n.T(i18n.Default(), i18n.MsgImportExternalID))
+		}
+	}
+	if len(errs) > 0 {
+		return fail(errs...)
+	}
+	if line, ok := seen[key]; ok {
+		return fail(i18n.T(i18n.Default(), i18n.MsgImportDuplicateRow, line))
+	}
+	seen[key] = row.Line
+
+	var (
+		existing *models.Menu
+		err      error
+	)
+	if opts.UpsertBy == models.ImportUpsertByExternalID {
+		existing, err = s.repo.FindByExternalID(key)
+	} else {
+		existing, err = s.repo.FindByName(row.Request.Name, row.Request.Category, s.uniqueName)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fail(itemError(err))
+	}
+
+	// menu baru
+	if existing == nil {
+		result.Action = models.ImportActionCreate
+		if opts.DryRun {
+			return result
+		}
+		menu, err := s.CreateMenu(row.Request)
+		if err != nil {
+			return fail(itemError(err))
+		}
+		result.ID = menu.ID
+		return result
+	}
+
+	result.ID = existing.ID
+	price := existing.Price
+	if !applyImportRow(existing, row) {
+		result.Action = models.ImportActionSkip
+		return result
+	}
+	if existing.Price != price {
+		if err := Authorize(ctx, auth.PermPriceWrite); err != nil {
+			return fail(itemError(err))
+		}
+	}
+
+	result.Action = models.ImportActionUpdate
+	if opts.DryRun {
+		return result
+	}
+	if err := s.checkDuplicate(s.repo, existing); err != nil {
+		return fail(itemError(err))
+	}
+	err = s.repo.Transaction(func(tx *repositories.MenuRepository) error {
+		if err := tx.Update(existing.ID, existing); err != nil {
+			return err
+		}
+		return s.recordEvent(tx, events.MenuUpdated, existing)
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicateName) {
+			return fail(itemError(s.duplicateError(existing)))
+		}
+		return fail(itemError(s.writeError(err)))
+	}
+	s.onSaved(existing)
+	return result
+}
+
+// key upsert_by=name: nama ternormalisasi, ditambah kategori kecuali nama unik global
+func importNameKey(name, category, scope string) string {
+	key := models.NormalizeMenuName(name)
+	if scope == models.UniqueNameGlobal {
+		return key
+	}
+	return category + "\x00" + key
+}
+
+// salin isi baris ke menu, return false kalau tidak ada yang berubah
+func applyImportRow(menu *models.Menu, row catalog.ImportRow) bool {
+	req := row.Request
+	updated := *menu
+	updated.Name = req.Name
+	updated.Category = req.Category
+	updated.Calories = req.Calories
+	updated.Price = req.Price
+	updated.Ingredients = pq.StringArray(req.Ingredients)
+	updated.Description = req.Description
+	// external_id lama dipertahankan kalau kolomnya kosong
+	if row.ExternalID != "" {
+		updated.ExternalID = &row.ExternalID
+	}
+
+	changed := updated.Name != menu.Name ||
+		updated.Category != menu.Category ||
+		!reflect.DeepEqual(updated.Calories, menu.Calories) ||
+		updated.Price != menu.Price ||
+		!reflect.DeepEqual([]string(updated.Ingredients), []string(menu.Ingredients)) ||
+		updated.Description != menu.Description ||
+		!reflect.DeepEqual(updated.ExternalID, menu.ExternalID)
+
+	*menu = updated
+	return changed
+}
+
+// pesan error satu baris/item dalam bahasa default; detail error internal (db dsb.)
+// hanya dicatat di log, tidak dikirim ke client
+func itemError(err error) string {
+	domain := Classify(err)
+	if domain.Kind == KindInternal {
+		log.Printf("Error internal item import/bulk: %v", err)
+		return i18n.T(i18n.Default(), i18n.MsgInternalHidden)
+	}
+	return domain.Error()
+}
+
+// pesan per field dari error validasi
+func validationMessages(err error) []string {
+	domain := Classify(err)
+	if len(domain.Fields) == 0 {
+		return []string{domain.Error()}
+	}
+
+	messages := make([]string, 0, len(domain.Fields))
+	for _, fe := range domain.Fields {
+		messages = append(messages, fe.Message)
+	}
+	return messages
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+
+	"GDGOC-API/internal/models"
+)
+
+func TestImportNameKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		a, b    [2]string
+		sameRow bool
+	}{
+		{name: "nama sama beda kategori", scope: models.UniqueNameCategory, a: [2]string{"Es Teh", "drinks"}, b: [2]string{"Es Teh", "desserts"}},
+		{name: "nama sama kategori sama", scope: models.UniqueNameCategory, a: [2]string{"Es Teh", "drinks"}, b: [2]string{"  es  TEH ", "drinks"}, sameRow: true},
+		{name: "scope off tetap per kategori", scope: models.UniqueNameOff, a: [2]string{"Es Teh", "drinks"}, b: [2]string{"Es Teh", "desserts"}},
+		{name: "scope global", scope: models.UniqueNameGlobal, a: [2]string{"Es Teh", "drinks"}, b: [2]string{"es teh", "desserts"}, sameRow: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := importNameKey(tt.a[0], tt.a[1], tt.scope)
+			b := importNameKey(tt.b[0], tt.b[1], tt.scope)
+			if (a == b) != tt.sameRow {
+				t.Fatalf("key %q vs %q: sama=%v, want %v", a, b, a == b, tt.sameRow)
+			}
+		})
+	}
+}
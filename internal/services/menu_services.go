@@ -1,7 +1,17 @@
 package services
 
 import(
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/patch"
 	"GDGOC-API/internal/models"
 	"GDGOC-API/internal/repositories"
 
@@ -10,25 +20,66 @@ import(
 	"gorm.io/gorm"
 )
 
+// nama menu sudah dipakai menu lain (sesuai MENU_UNIQUE_NAME)
+type DuplicateMenuError struct{
+	ExistingID	uint
+	Name	string
+}
+
+func (e *DuplicateMenuError) Error() string{
+	return i18n.T(i18n.Default(), i18n.MsgDuplicateMenu, e.Name, e.ExistingID)
+}
+
 type MenuService struct{
 	repo	*repositories.MenuRepository
 	validate	*validator.Validate
+	semantic	*SemanticSearchService
+	uniqueName	string
+	relay	*OutboxRelay
 }
 
-func NewMenuService(repo *repositories.MenuRepository) *MenuService{
+// semantic boleh nil kalau pencarian semantik tidak dipakai,
+// uniqueName: category, global, atau off,
+// relay boleh nil (mis. CLI), event perubahan tetap ditulis ke outbox dan dikirim relay server
+func NewMenuService(repo *repositories.MenuRepository, semantic *SemanticSearchService, uniqueName string, relay *OutboxRelay) *MenuService{
 	return &MenuService{
 		repo:	repo,
-		validate:	validator.New(),
+		validate:	newValidator(),
+		semantic:	semantic,
+		uniqueName:	uniqueName,
+		relay:	relay,
 	}
 }
 
 // create menu baru
 func (s *MenuService) CreateMenu(req models.CreateMenuRequest) (*models.Menu, error){
-	if err := s.validate.Struct(req); err != nil{
+	var menu *models.Menu
+	err := s.repo.Transaction(func(tx *repositories.MenuRepository) error{
+		var err error
+		if menu, err = s.createMenu(tx, req); err != nil{
+			return err
+		}
+		return s.recordEvent(tx, events.MenuCreated, menu)
+	})
+	if err != nil{
 		return nil, err
 	}
+	s.onSaved(menu)
+	return menu, nil
+}
+
+func (s *MenuService) createMenu(repo *repositories.MenuRepository, req models.CreateMenuRequest) (*models.Menu, error){
+	if err := s.validate.Struct(req); err != nil{
+		return nil, validationError(err)
+	}
+
+	// external_id kosong disimpan NULL supaya tidak bentrok di unique index
+	if req.ExternalID != nil && strings.TrimSpace(*req.ExternalID) == ""{
+		req.ExternalID = nil
+	}
 
 	menu := &models.Menu{
+		ExternalID:	req.ExternalID,
 		Name:	req.Name,
 		Category:	req.Category,
 		Calories:	req.Calories,
@@ -36,7 +87,13 @@ func (s *MenuService) CreateMenu(req models.CreateMenuRequest) (*models.Menu, er
 		Ingredients:	pq.StringArray(req.Ingredients),
 		Description:	req.Description,
 	}
-	if err := s.repo.Create(menu); err != nil{
+	if err := s.checkDuplicate(repo, menu); err != nil{
+		return nil, err
+	}
+	if err := repo.Create(menu); err != nil{
+		if errors.Is(err, repositories.ErrDuplicateName){
+			return nil, s.duplicateError(menu)
+		}
 		return nil, err
 	}
 	return menu, nil
@@ -61,32 +118,65 @@ func (s *MenuService) GetAllMenus(filters models.MenuFilters) ([]models.Menu, *m
 	return menus,pagination, nil
 }
 
-// get menu by id
-func (s *MenuService) GetMenuByID(id uint) (*models.Menu, error){
-	menu, err := s.repo.GetByID(id)
+// get menu by id, view untuk fields= & include=
+func (s *MenuService) GetMenuByID(id uint, view models.MenuView) (*models.Menu, error){
+	menu, err := s.repo.GetByIDView(id, view)
 	if err != nil{
 		if errors.Is(err, gorm.ErrRecordNotFound){
-			return nil, errors.New("menu tidak ditemukan")
+			return nil, ErrMenuNotFound
 		}
 		return nil, err
 	}
 	return menu, nil
 }
 
-// update menu
-func (s *MenuService) UpdateMenu(id uint, req models.UpdateMenuRequest) (*models.Menu, error){
-	if err := s.validate.Struct(req); err != nil{
+// hitung popularity, dipanggil REST GET /menu/:id setelah response 200 (bukan 304,
+// gRPC atau GraphQL). Gagal increment tidak menggagalkan request
+func (s *MenuService) RecordView(id uint){
+	if err := s.repo.IncrementViews(id); err != nil{
+		log.Printf("Gagal update view count menu %d: %v", id, err)
+	}
+}
+
+// update menu, expectedVersion nil = tanpa cek versi dari client.
+// Mengubah harga butuh permission price:write dari principal di ctx
+func (s *MenuService) UpdateMenu(ctx context.Context, id uint, req models.UpdateMenuRequest, expectedVersion *uint) (*models.Menu, error){
+	var menu *models.Menu
+	err := s.repo.Transaction(func(tx *repositories.MenuRepository) error{
+		var err error
+		if menu, err = s.updateMenu(ctx, tx, id, req, expectedVersion); err != nil{
+			return err
+		}
+		return s.recordEvent(tx, events.MenuUpdated, menu)
+	})
+	if err != nil{
 		return nil, err
 	}
+	s.onSaved(menu)
+	return menu, nil
+}
+
+func (s *MenuService) updateMenu(ctx context.Context, repo *repositories.MenuRepository, id uint, req models.UpdateMenuRequest, expectedVersion *uint) (*models.Menu, error){
+	if err := s.validate.Struct(req); err != nil{
+		return nil, validationError(err)
+	}
 
 	// cek ketersediaan menu
-	existing, err := s.repo.GetByID(id)
+	existing, err := repo.GetByID(id)
 	if err != nil{
 		if errors.Is(err, gorm.ErrRecordNotFound){
-			return nil, errors.New("menu tidak ditemukan")
+			return nil, ErrMenuNotFound
 		}
 		return nil, err
 	}
+	if expectedVersion != nil && existing.Version != *expectedVersion{
+		return nil, ErrPreconditionFailed
+	}
+	if req.Price != existing.Price{
+		if err := Authorize(ctx, auth.PermPriceWrite); err != nil{
+			return nil, err
+		}
+	}
 
 	//update field
 	existing.Name = req.Name
@@ -96,24 +186,191 @@ func (s *MenuService) UpdateMenu(id uint, req models.UpdateMenuRequest) (*models
 	existing.Ingredients = pq.StringArray(req.Ingredients)
 	existing.Description= req.Description
 
-	if err := s.repo.Update(id, existing); err != nil{
+	if err := s.checkDuplicate(repo, existing); err != nil{
 		return nil, err
 	}
+	if err := repo.Update(id, existing); err != nil{
+		if errors.Is(err, repositories.ErrDuplicateName){
+			return nil, s.duplicateError(existing)
+		}
+		return nil, s.writeError(err)
+	}
 
 	return existing, nil
 }
 
+// ganti varian, tag & gambar menu. Versi menu naik & event menu.updated dikirim
+// seperti update biasa. Mengubah varian (ada harga) butuh permission price:write
+func (s *MenuService) UpdateMenuRelations(ctx context.Context, id uint, req models.UpdateMenuRelationsRequest, expectedVersion *uint) (*models.Menu, error){
+	if err := s.validate.Struct(req); err != nil{
+		return nil, validationError(err)
+	}
+	if req.Variants == nil && req.Tags == nil && req.Images == nil{
+		return nil, Invalid(CodeInvalidRequest, i18n.MsgRelationsEmpty)
+	}
+	if req.Variants != nil{
+		if err := Authorize(ctx, auth.PermPriceWrite); err != nil{
+			return nil, err
+		}
+	}
+
+	var menu *models.Menu
+	err := s.repo.Transaction(func(tx *repositories.MenuRepository) error{
+		existing, err := tx.GetByID(id)
+		if err != nil{
+			if errors.Is(err, gorm.ErrRecordNotFound){
+				return ErrMenuNotFound
+			}
+			return err
+		}
+		if expectedVersion != nil && existing.Version != *expectedVersion{
+			return ErrPreconditionFailed
+		}
+
+		if req.Variants != nil{
+			variants := make([]models.MenuVariant, len(*req.Variants))
+			for i, v := range *req.Variants{
+				variants[i] = models.MenuVariant{Name: strings.TrimSpace(v.Name), Price: v.Price}
+			}
+			if err := tx.ReplaceVariants(id, variants); err != nil{
+				return err
+			}
+		}
+		if req.Tags != nil{
+			if err := tx.ReplaceTags(id, normalizeTags(*req.Tags)); err != nil{
+				return err
+			}
+		}
+		if req.Images != nil{
+			images := make([]models.MenuImage, len(*req.Images))
+			for i, img := range *req.Images{
+				images[i] = models.MenuImage{URL: img.URL, Alt: img.Alt, Position: img.Position}
+			}
+			if err := tx.ReplaceImages(id, images); err != nil{
+				return err
+			}
+		}
+
+		// naikkan versi supaya ETag & cache client ikut berubah
+		if err := tx.Update(id, existing); err != nil{
+			return s.writeError(err)
+		}
+		if menu, err = tx.GetByIDView(id, models.MenuView{Include: "variants,tags,images"}); err != nil{
+			return err
+		}
+		return s.recordEvent(tx, events.MenuUpdated, menu)
+	})
+	if err != nil{
+		return nil, err
+	}
+	s.onSaved(menu)
+	return menu, nil
+}
+
+// tag lowercase tanpa spasi berlebih & tanpa duplikat
+func normalizeTags(tags []string) []string{
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags{
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if tag == "" || seen[tag]{
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// update sebagian menu pakai JSON Merge Patch / JSON Patch,
+// hasil merge divalidasi dengan aturan yang sama seperti PUT
+func (s *MenuService) PatchMenu(ctx context.Context, id uint, patchDoc []byte, contentType string, expectedVersion *uint) (*models.Menu, error){
+	existing, err := s.repo.GetByID(id)
+	if err != nil{
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			return nil, ErrMenuNotFound
+		}
+		return nil, err
+	}
+	if expectedVersion != nil && existing.Version != *expectedVersion{
+		return nil, ErrPreconditionFailed
+	}
+
+	current, err := json.Marshal(models.UpdateMenuRequest{
+		Name:	existing.Name,
+		Category:	existing.Category,
+		Calories:	existing.Calories,
+		Price:	existing.Price,
+		Ingredients:	existing.Ingredients,
+		Description:	existing.Description,
+	})
+	if err != nil{
+		return nil, err
+	}
+
+	var merged []byte
+	switch contentType{
+	case patch.ContentTypeJSONPatch:
+		merged, err = patch.JSONPatch(current, patchDoc)
+	default:
+		merged, err = patch.MergePatch(current, patchDoc)
+	}
+	if err != nil{
+		return nil, err
+	}
+
+	var req models.UpdateMenuRequest
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil{
+		return nil, fmt.Errorf("%w: hasil patch tidak sesuai skema menu: %v", patch.ErrInvalidPatch, err)
+	}
+
+	// patch diterapkan ke versi yang dibaca di atas,
+	// hasil patch yang tidak lolos validasi = 422
+	menu, err := s.UpdateMenu(ctx, id, req, &existing.Version)
+	var domain *Error
+	if errors.As(err, &domain) && domain.Code == CodeValidation{
+		unprocessable := *domain
+		unprocessable.Kind = KindUnprocessable
+		return nil, &unprocessable
+	}
+	return menu, err
+}
+
 // hapus menu by id
-func (s *MenuService) DeleteMenu(id uint) error{
-	_, err := s.repo.GetByID(id)
+func (s *MenuService) DeleteMenu(id uint, expectedVersion *uint) error{
+	var menu *models.Menu
+	err := s.repo.Transaction(func(tx *repositories.MenuRepository) error{
+		var err error
+		if menu, err = s.deleteMenu(tx, id, expectedVersion); err != nil{
+			return err
+		}
+		return s.recordEvent(tx, events.MenuDeleted, menu)
+	})
+	if err != nil{
+		return err
+	}
+	s.onDeleted(menu)
+	return nil
+}
+
+func (s *MenuService) deleteMenu(repo *repositories.MenuRepository, id uint, expectedVersion *uint) (*models.Menu, error){
+	existing, err := repo.GetByID(id)
 	if err != nil{
 		if errors.Is(err, gorm.ErrRecordNotFound){
-			return errors.New("menu tidak ditemukan")
+			return nil, ErrMenuNotFound
 		}
-	return err
+		return nil, err
+	}
+	if expectedVersion != nil && existing.Version != *expectedVersion{
+		return nil, ErrPreconditionFailed
 	}
 
-	return s.repo.Delete(id)
+	if err := repo.Delete(id, existing.Version); err != nil{
+		return nil, s.writeError(err)
+	}
+	return existing, nil
 }
 
 // grouping menu by kategori
@@ -135,18 +392,97 @@ func (s *MenuService) GroupMenusByCategory(mode string, perCategory int) (interf
 }
 
 // search
-func (s *MenuService) SearchMenus(query string, page, perPage int) ([]models.Menu, *models.PaginationMeta, error) {
-	if page < 1{
-		page = 1
+func (s *MenuService) SearchMenus(query string, params models.PageParams) ([]models.Menu, *models.PaginationMeta, error) {
+	if params.Page < 1{
+		params.Page = 1
+	}
+	if params.PerPage < 1{
+		params.PerPage = 10
+	}
+	if params.PerPage > 100{
+		params.PerPage = 100
 	}
-	if perPage < 1{
-		perPage = 10
+
+	return s.repo.Search(query, params)
+}
+
+// pencarian semantik / hybrid
+func (s *MenuService) SemanticSearch(query, mode string, limit int, alpha float64) ([]models.SemanticSearchResult, error){
+	if s.semantic == nil{
+		return nil, ErrSemanticUnavailable
+	}
+	return s.semantic.Search(query, mode, limit, alpha)
+}
+
+// terjemahkan error repo saat update/delete
+func (s *MenuService) writeError(err error) error{
+	if errors.Is(err, gorm.ErrRecordNotFound){
+		return ErrMenuNotFound
+	}
+	if errors.Is(err, repositories.ErrVersionConflict){
+		return ErrPreconditionFailed
+	}
+	return err
+}
+
+// tolak nama yang sudah dipakai menu lain sebelum menulis ke db,
+// index unik di db tetap jadi pengaman kalau ada request bersamaan
+func (s *MenuService) checkDuplicate(repo *repositories.MenuRepository, menu *models.Menu) error{
+	if s.uniqueName != models.UniqueNameCategory && s.uniqueName != models.UniqueNameGlobal{
+		return nil
+	}
+
+	existing, err := repo.FindDuplicate(menu.Name, menu.Category, s.uniqueName, menu.ID)
+	if err != nil{
+		if errors.Is(err, gorm.ErrRecordNotFound){
+			return nil
+		}
+		return err
+	}
+	return &DuplicateMenuError{ExistingID: existing.ID, Name: existing.Name}
+}
+
+// error duplikat dari index unik, cari id menu yang bentrok
+// (dicari di luar transaksi, transaksi yang kena unique violation sudah batal)
+func (s *MenuService) duplicateError(menu *models.Menu) error{
+	var duplicate *DuplicateMenuError
+	if err := s.checkDuplicate(s.repo, menu); errors.As(err, &duplicate){
+		return err
+	}
+	// menu pemenang belum terlihat (transaksi lain), id tidak diketahui
+	return &DuplicateMenuError{Name: menu.Name}
+}
+
+// tulis event perubahan ke outbox memakai transaksi repo, ikut batal kalau perubahan menu batal
+func (s *MenuService) recordEvent(repo *repositories.MenuRepository, typ events.Type, menu *models.Menu) error{
+	event, err := newOutboxEvent(typ, menu)
+	if err != nil{
+		return err
+	}
+	return repo.Outbox().Append(event)
+}
+
+// efek samping setelah menu tersimpan (create/update) & commit,
+// gagal embed tidak menggagalkan penyimpanan menu
+func (s *MenuService) onSaved(menu *models.Menu){
+	s.relay.Notify()
+	if s.semantic == nil{
+		return
 	}
-	if perPage > 100{
-		perPage = 100
+	if err := s.semantic.IndexMenu(menu); err != nil{
+		log.Printf("Gagal embed menu %d: %v", menu.ID, err)
 	}
+}
 
-	return s.repo.Search(query, page, perPage)
+// efek samping setelah menu dihapus & commit
+func (s *MenuService) onDeleted(menu *models.Menu){
+	s.relay.Notify()
+	if s.semantic == nil{
+		return
+	}
+	if err := s.semantic.RemoveMenu(menu.ID); err != nil{
+		log.Printf("Gagal hapus embedding menu %d: %v", menu.ID, err)
+	}
 }
 
 // cek menu by id
@@ -154,7 +490,7 @@ func (s *MenuService) ValidateMenuExists(id uint) error{
 	_, err := s.repo.GetByID(id)
 	if err != nil{
 		if errors.Is(err, gorm.ErrRecordNotFound){
-			return errors.New("menu tidak ditemukan")
+			return ErrMenuNotFound
 		}
 		return err
 	}
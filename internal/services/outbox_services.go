@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+)
+
+// jumlah event yang diambil relay per putaran
+const outboxBatchSize = 20
+
+// pengaturan relay outbox
+type OutboxOptions struct {
+	// interval cek event jatuh tempo (cadangan kalau Notify tidak terpanggil, mis. dari CLI import)
+	PollInterval time.Duration
+	// timeout satu sink untuk satu event
+	Timeout time.Duration
+	// jeda retry ke-n = BackoffBase * 2^(n-1) (+ jitter), maksimal BackoffMax
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// jumlah percobaan sebelum event diparkir, 0 = default 20
+	MaxAttempts int
+	// event terkirim disimpan selama Retention lalu dihapus, 0 = tidak pernah dihapus
+	Retention time.Duration
+}
+
+// kirim event dari tabel outbox ke sink. At-least-once: event baru ditandai terkirim
+// setelah semua sink berhasil, yang gagal dicoba ulang dengan backoff sampai MaxAttempts
+// lalu diparkir. Urutan per menu dijaga: event berikutnya untuk menu yang sama menunggu
+// event sebelumnya terkirim atau diparkir
+type OutboxRelay struct {
+	repo  *repositories.OutboxRepository
+	sinks []events.Sink
+	opts  OutboxOptions
+	wake  chan struct{}
+}
+
+func NewOutboxRelay(repo *repositories.OutboxRepository, sinks []events.Sink, opts OutboxOptions) *OutboxRelay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.BackoffBase <= 0 {
+		opts.BackoffBase = time.Second
+	}
+	if opts.BackoffMax <= 0 {
+		opts.BackoffMax = time.Minute
+	}
+	if opts.BackoffMax < opts.BackoffBase {
+		opts.BackoffMax = opts.BackoffBase
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 20
+	}
+	return &OutboxRelay{
+		repo:  repo,
+		sinks: sinks,
+		opts:  opts,
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// bangunkan relay tanpa menunggu PollInterval, dipanggil setelah transaksi menu commit
+func (r *OutboxRelay) Notify() {
+	if r == nil {
+		return
+	}
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// jalankan relay & pembersihan event lama di background
+func (r *OutboxRelay) Start() {
+	go r.run()
+	if r.opts.Retention > 0 {
+		go r.cleanup()
+	}
+}
+
+func (r *OutboxRelay) run() {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		r.relayDue()
+		select {
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// kirim semua event jatuh tempo sampai habis
+func (r *OutboxRelay) relayDue() {
+	// lease cukup untuk semua sink di satu batch, lewat dari itu event diambil ulang
+	lease := time.Duration(len(r.sinks)*outboxBatchSize)*r.opts.Timeout + time.Minute
+	for {
+		batch, err := r.repo.ClaimDue(time.Now(), lease, outboxBatchSize)
+		if err != nil {
+			log.Printf("Outbox: gagal mengambil event: %v", err)
+			return
+		}
+		if len(batch) == 0 {
+			return
+		}
+		for i := range batch {
+			r.relay(&batch[i])
+		}
+	}
+}
+
+// kirim satu event ke sink yang belum menerimanya
+func (r *OutboxRelay) relay(row *models.OutboxEvent) {
+	event, err := outboxToEvent(row)
+	if err != nil {
+		// payload rusak tidak akan pernah berhasil, langsung diparkir
+		row.Attempts++
+		r.park(row, fmt.Errorf("payload tidak valid: %w", err))
+		return
+	}
+
+	done := make(map[string]bool, len(row.SinksDone))
+	for _, name := range row.SinksDone {
+		done[name] = true
+	}
+
+	var failures []string
+	for _, sink := range r.sinks {
+		if done[sink.Name()] {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), r.opts.Timeout)
+		err := sink.Publish(ctx, event)
+		cancel()
+		if err != nil {
+			failures = append(failures, sink.Name()+": "+err.Error())
+			continue
+		}
+		row.SinksDone = append(row.SinksDone, sink.Name())
+	}
+
+	if len(failures) > 0 {
+		row.Attempts++
+		err := errors.New(strings.Join(failures, "; "))
+		if row.Attempts >= r.opts.MaxAttempts {
+			r.park(row, err)
+			return
+		}
+		r.fail(row, err, r.backoff(row.Attempts))
+		return
+	}
+	row.LastError = ""
+	if err := r.repo.MarkPublished(row, time.Now()); err != nil {
+		// event akan dikirim ulang setelah lease habis, sink yang tercatat berhasil dilewati
+		log.Printf("Outbox: gagal menandai event #%d terkirim: %v", row.ID, err)
+	}
+}
+
+func (r *OutboxRelay) fail(row *models.OutboxEvent, err error, delay time.Duration) {
+	row.LastError = err.Error()
+	row.NextAttemptAt = time.Now().Add(delay)
+	log.Printf("Outbox: event #%d (%s menu %d) gagal, percobaan ke-%d dalam %s: %v",
+		row.ID, row.EventType, row.AggregateID, row.Attempts, delay.Round(time.Second), err)
+	if err := r.repo.MarkFailed(row); err != nil {
+		log.Printf("Outbox: gagal menyimpan status event #%d: %v", row.ID, err)
+	}
+}
+
+// hentikan percobaan event supaya event berikutnya untuk menu yang sama tidak tertahan
+func (r *OutboxRelay) park(row *models.OutboxEvent, err error) {
+	row.LastError = err.Error()
+	log.Printf("Outbox: PERHATIAN event #%d (%s menu %d versi %d) diparkir setelah %d percobaan, tidak dikirim lagi (sink selesai: %v): %v",
+		row.ID, row.EventType, row.AggregateID, row.Version, row.Attempts, []string(row.SinksDone), err)
+	if err := r.repo.MarkParked(row, time.Now()); err != nil {
+		log.Printf("Outbox: gagal memarkir event #%d: %v", row.ID, err)
+	}
+}
+
+// jeda sebelum percobaan berikutnya, sama seperti backoff webhook
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := r.opts.BackoffBase
+	for i := 1; i < attempts && delay < r.opts.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > r.opts.BackoffMax {
+		delay = r.opts.BackoffMax
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// hapus event terkirim yang lebih lama dari Retention, sekali per jam
+func (r *OutboxRelay) cleanup() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := r.repo.DeletePublishedBefore(time.Now().Add(-r.opts.Retention))
+		if err != nil {
+			log.Printf("Outbox: gagal menghapus event lama: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Outbox: %d event lama dihapus", deleted)
+		}
+	}
+}
+
+// baris outbox untuk perubahan menu, dipanggil di dalam transaksi perubahan menunya
+func newOutboxEvent(typ events.Type, menu *models.Menu) (*models.OutboxEvent, error) {
+	payload, err := json.Marshal(menu)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OutboxEvent{
+		AggregateID:   menu.ID,
+		EventType:     string(typ),
+		Version:       menu.Version,
+		Payload:       string(payload),
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+func outboxToEvent(row *models.OutboxEvent) (events.Event, error) {
+	var menu models.Menu
+	if err := json.Unmarshal([]byte(row.Payload), &menu); err != nil {
+		return events.Event{}, err
+	}
+	return events.Event{
+		ID:         row.ID,
+		Type:       events.Type(row.EventType),
+		MenuID:     row.AggregateID,
+		Version:    row.Version,
+		Menu:       &menu,
+		OccurredAt: row.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"GDGOC-API/internal/auth"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// principal tidak punya permission yang dibutuhkan (403)
+type PermissionError struct {
+	Permission string
+}
+
+func (e *PermissionError) Error() string {
+	return i18n.T(i18n.Default(), i18n.MsgForbidden, e.Permission)
+}
+
+// cek permission principal di ctx. Tanpa principal = pemanggil internal
+// (CLI, AUTH_ENABLED=false), route & RPC yang butuh login sudah ditolak sebelumnya
+func Authorize(ctx context.Context, perm string) error {
+	if ctx == nil {
+		return nil
+	}
+	principal := auth.FromContext(ctx)
+	if principal == nil || principal.Can(perm) {
+		return nil
+	}
+	return &PermissionError{Permission: perm}
+}
+
+// semua role bawaan beserta permission-nya
+func (s *AuthService) ListRoles() []models.RoleResponse {
+	roles := make([]models.RoleResponse, 0, len(auth.Roles()))
+	for _, role := range auth.Roles() {
+		roles = append(roles, models.RoleResponse{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+		})
+	}
+	return roles
+}
+
+func (s *AuthService) ListRoleAssignments(principal string) ([]models.RoleAssignment, error) {
+	return s.repo.ListRoleAssignments(principal)
+}
+
+// beri role ke user / API key
+func (s *AuthService) AssignRole(req models.CreateRoleAssignmentRequest, createdBy *auth.Principal) (*models.RoleAssignment, error) {
+	if err := s.validateStruct(req); err != nil {
+		return nil, err
+	}
+	if _, ok := auth.LookupRole(req.Role); !ok {
+		names := make([]string, 0, len(auth.Roles()))
+		for _, role := range auth.Roles() {
+			names = append(names, role.Name)
+		}
+		return nil, Invalid(CodeUnknownRole, i18n.MsgUnknownRole, req.Role, strings.Join(names, ", "))
+	}
+	if err := s.checkPrincipal(req.Principal); err != nil {
+		return nil, err
+	}
+
+	assignment := &models.RoleAssignment{Principal: req.Principal, Role: req.Role}
+	if createdBy != nil {
+		assignment.CreatedBy = createdBy.Key()
+	}
+	err := s.repo.CreateRoleAssignment(assignment)
+	if errors.Is(err, repositories.ErrDuplicateRole) {
+		return nil, Errorf(KindConflict, CodeDuplicateRole, i18n.MsgDuplicateRole, req.Principal, req.Role)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// cabut role assignment, admin terakhir tidak bisa dicabut supaya tidak terkunci
+func (s *AuthService) UnassignRole(id uint) (*models.RoleAssignment, error) {
+	assignment, err := s.repo.GetRoleAssignment(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if assignment.Role == auth.RoleAdmin {
+		admins, err := s.repo.CountRole(auth.RoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if admins <= 1 {
+			return nil, ErrLastAdmin
+		}
+	}
+	if err := s.repo.DeleteRoleAssignment(id); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// principal harus user:<id> / api_key:<id> yang ada. Subject user bukan angka
+// (token dari issuer lain) diterima apa adanya
+func (s *AuthService) checkPrincipal(key string) error {
+	typ, subject, ok := strings.Cut(key, ":")
+	if !ok || subject == "" {
+		return ErrInvalidPrincipal
+	}
+
+	var err error
+	switch typ {
+	case auth.PrincipalUser:
+		id, parseErr := strconv.ParseUint(subject, 10, 32)
+		if parseErr != nil {
+			return nil
+		}
+		_, err = s.repo.GetUser(uint(id))
+	case auth.PrincipalAPIKey:
+		id, parseErr := strconv.ParseUint(subject, 10, 32)
+		if parseErr != nil {
+			return ErrInvalidPrincipal
+		}
+		_, err = s.repo.GetAPIKey(uint(id))
+	default:
+		return ErrInvalidPrincipal
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidPrincipal
+	}
+	return err
+}
+
+// isi role & permission principal dari role assignment
+func (s *AuthService) loadRoles(principal *auth.Principal) (*auth.Principal, error) {
+	roles, err := s.repo.RolesOf(principal.Key())
+	if err != nil {
+		return nil, err
+	}
+	principal.SetRoles(roles)
+	return principal, nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"GDGOC-API/internal/embedding"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+)
+
+const (
+	SearchModeVector = "vector"
+	SearchModeHybrid = "hybrid"
+
+	// batas kandidat per sumber (vektor & keyword), sama dengan max per_page repo
+	maxSearchCandidates = 100
+	// batas waktu embed menu yang belum punya vektor saat start
+	warmupTimeout = 10 * time.Minute
+)
+
+// pencarian semantik pakai embedding menu
+type SemanticSearchService struct {
+	repo     *repositories.MenuRepository
+	embRepo  *repositories.EmbeddingRepository
+	provider embedding.Provider
+	index    *embedding.Index
+}
+
+func NewSemanticSearchService(repo *repositories.MenuRepository, embRepo *repositories.EmbeddingRepository, provider embedding.Provider) *SemanticSearchService {
+	return &SemanticSearchService{
+		repo:     repo,
+		embRepo:  embRepo,
+		provider: provider,
+		index:    embedding.NewIndex(),
+	}
+}
+
+// load vektor dari db ke index. Menu yang belum punya vektor di-embed di background
+// (provider Gemini = satu API call per menu) supaya server tidak menunggu
+func (s *SemanticSearchService) Warmup() error {
+	stored, err := s.embRepo.GetByProvider(s.provider.Name())
+	if err != nil {
+		return err
+	}
+	for _, e := range stored {
+		s.index.Upsert(e.MenuID, []float32(e.Vector))
+	}
+	log.Printf("Index semantik: %d vektor dimuat (provider %s)", s.index.Len(), s.provider.Name())
+
+	go s.backfill(warmupTimeout)
+	return nil
+}
+
+// embed menu yang belum ada di index, berhenti kalau timeout habis
+func (s *SemanticSearchService) backfill(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	embedded := 0
+	err := s.repo.Each(100, func(menus []models.Menu) error {
+		for i := range menus {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if s.index.Has(menus[i].ID) {
+				continue
+			}
+			if err := s.indexMenu(ctx, &menus[i]); err != nil {
+				log.Printf("Gagal embed menu %d: %v", menus[i].ID, err)
+				continue
+			}
+			embedded++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Embed menu di background berhenti: %v (%d menu baru di-embed)", err, embedded)
+		return
+	}
+	log.Printf("Index semantik siap: %d menu (%d baru di-embed, provider %s)", s.index.Len(), embedded, s.provider.Name())
+}
+
+// hitung & simpan vektor untuk satu menu
+func (s *SemanticSearchService) IndexMenu(menu *models.Menu) error {
+	return s.indexMenu(context.Background(), menu)
+}
+
+func (s *SemanticSearchService) indexMenu(ctx context.Context, menu *models.Menu) error {
+	vec, err := s.provider.Embed(ctx, embedding.MenuText(*menu))
+	if err != nil {
+		return err
+	}
+
+	if err := s.embRepo.Upsert(&models.MenuEmbedding{
+		MenuID:   menu.ID,
+		Provider: s.provider.Name(),
+		Vector:   vec,
+	}); err != nil {
+		return err
+	}
+
+	s.index.Upsert(menu.ID, vec)
+	return nil
+}
+
+// buang menu dari index
+func (s *SemanticSearchService) RemoveMenu(id uint) error {
+	s.index.Delete(id)
+	return s.embRepo.Delete(id)
+}
+
+// cari menu by makna query, mode hybrid menggabungkan skor vektor & keyword
+// dengan bobot alpha untuk vektor
+func (s *SemanticSearchService) Search(query, mode string, limit int, alpha float64) ([]models.SemanticSearchResult, error) {
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if mode != SearchModeHybrid {
+		mode = SearchModeVector
+	}
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.7
+	}
+
+	queryVec, err := s.provider.Embed(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	// kandidat lebih banyak dari limit, biar ranking hybrid punya ruang.
+	// Dibatasi sama untuk vektor & keyword (repo.Search maksimal 100 per halaman)
+	candidates := limit
+	if mode == SearchModeHybrid {
+		candidates = limit * 5
+		if candidates > maxSearchCandidates {
+			candidates = maxSearchCandidates
+		}
+	}
+
+	vectorScores := make(map[uint]float64)
+	for _, m := range s.index.Search(queryVec, candidates) {
+		vectorScores[m.ID] = m.Score
+	}
+
+	if mode == SearchModeHybrid {
+		keywordMenus, _, err := s.repo.Search(query, models.PageParams{Page: 1, PerPage: candidates})
+		if err != nil {
+			return nil, err
+		}
+		for _, menu := range keywordMenus {
+			if _, ok := vectorScores[menu.ID]; ok {
+				continue
+			}
+			if vec, ok := s.index.Get(menu.ID); ok {
+				vectorScores[menu.ID] = embedding.Cosine(queryVec, vec)
+			} else {
+				vectorScores[menu.ID] = 0
+			}
+		}
+	}
+
+	ids := make([]uint, 0, len(vectorScores))
+	for id := range vectorScores {
+		ids = append(ids, id)
+	}
+	menus, err := s.repo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	queryTokens := embedding.Tokenize(query)
+	results := make([]models.SemanticSearchResult, 0, len(menus))
+	for _, menu := range menus {
+		result := models.SemanticSearchResult{
+			Menu:        menu,
+			VectorScore: vectorScores[menu.ID],
+		}
+		result.Score = result.VectorScore
+
+		if mode == SearchModeHybrid {
+			result.KeywordScore = keywordScore(queryTokens, menu)
+			result.Score = alpha*result.VectorScore + (1-alpha)*result.KeywordScore
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].Menu.ID < results[j].Menu.ID
+		}
+		return results[i].Score > results[j].Score
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// proporsi token query yang muncul di teks menu (0..1)
+func keywordScore(queryTokens []string, menu models.Menu) float64 {
+	if len(queryTokens) == 0 {
+		return 0
+	}
+
+	menuText := strings.ToLower(embedding.MenuText(menu))
+	matched := 0
+	for _, token := range queryTokens {
+		if strings.Contains(menuText, token) {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(queryTokens))
+}
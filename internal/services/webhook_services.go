@@ -0,0 +1,448 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"GDGOC-API/internal/events"
+	"GDGOC-API/internal/i18n"
+	"GDGOC-API/internal/models"
+	"GDGOC-API/internal/repositories"
+	"GDGOC-API/internal/webhook"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+const (
+	// jumlah pengiriman yang diambil & dikirim paralel per putaran worker
+	webhookBatchSize = 20
+	// isi response penerima yang disimpan di log percobaan
+	webhookResponseLimit = 1024
+	webhookUserAgent     = "MenuCatalog-Webhook/1.0"
+)
+
+// pengaturan pengiriman webhook
+type WebhookOptions struct {
+	// jumlah percobaan sebelum masuk dead-letter
+	MaxAttempts int
+	// jeda retry ke-n = BackoffBase * 2^(n-1) (+ jitter), maksimal BackoffMax
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	Timeout     time.Duration
+	// interval cek pengiriman jatuh tempo
+	PollInterval time.Duration
+	// izinkan URL ke localhost / IP private (development, webhook-receiver lokal)
+	AllowPrivateNetworks bool
+}
+
+// langganan webhook & pengiriman event katalog (retry dengan exponential backoff, dead-letter, redelivery)
+type WebhookService struct {
+	repo     *repositories.WebhookRepository
+	validate *validator.Validate
+	client   *http.Client
+	opts     WebhookOptions
+	wake     chan struct{}
+}
+
+func NewWebhookService(repo *repositories.WebhookRepository, opts WebhookOptions) *WebhookService {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	return &WebhookService{
+		repo:     repo,
+		validate: newValidator(),
+		client:   webhook.NewClient(opts.Timeout, opts.AllowPrivateNetworks),
+		opts:     opts,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// buat webhook, secret kosong dibuatkan server. Secret hanya dikembalikan di sini
+func (s *WebhookService) Create(req models.CreateWebhookRequest) (*models.Webhook, string, error) {
+	if err := s.validateStruct(req); err != nil {
+		return nil, "", err
+	}
+	if err := s.checkURL(req.URL); err != nil {
+		return nil, "", err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := webhook.NewSecret()
+		if err != nil {
+			return nil, "", err
+		}
+		secret = generated
+	}
+
+	hook := &models.Webhook{
+		URL:         req.URL,
+		Secret:      secret,
+		Events:      uniqueStrings(req.Events),
+		Description: req.Description,
+		Active:      true,
+	}
+	if err := s.repo.Create(hook); err != nil {
+		return nil, "", err
+	}
+	return hook, secret, nil
+}
+
+func (s *WebhookService) List() ([]models.Webhook, error) {
+	return s.repo.List()
+}
+
+func (s *WebhookService) Get(id uint) (*models.Webhook, error) {
+	hook, err := s.repo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookNotFound
+	}
+	return hook, err
+}
+
+// ganti URL, event & deskripsi; active nil = tidak berubah
+func (s *WebhookService) Update(id uint, req models.UpdateWebhookRequest) (*models.Webhook, error) {
+	if err := s.validateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkURL(req.URL); err != nil {
+		return nil, err
+	}
+	hook, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	hook.URL = req.URL
+	hook.Events = uniqueStrings(req.Events)
+	hook.Description = req.Description
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+	if err := s.repo.Update(hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (s *WebhookService) Delete(id uint) error {
+	err := s.repo.Delete(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+// jadwalkan event webhook.ping ke satu webhook untuk menguji URL & verifikasi signature
+func (s *WebhookService) Ping(id uint) (*models.WebhookDelivery, error) {
+	hook, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !hook.Active {
+		return nil, ErrWebhookInactive
+	}
+
+	deliveries, err := s.deliveries([]models.Webhook{*hook}, events.Event{Type: models.WebhookEventPing, OccurredAt: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+	s.notify()
+	return &deliveries[0], nil
+}
+
+// daftar pengiriman (status dead = dead-letter), terbaru dulu
+func (s *WebhookService) ListDeliveries(filters models.WebhookDeliveryFilters) ([]models.WebhookDelivery, *models.PaginationMeta, error) {
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.PerPage < 1 {
+		filters.PerPage = 20
+	}
+	if filters.PerPage > 100 {
+		filters.PerPage = 100
+	}
+	if filters.WebhookID != 0 {
+		if _, err := s.Get(filters.WebhookID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	deliveries, total, err := s.repo.ListDeliveries(filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalPages := int((total + int64(filters.PerPage) - 1) / int64(filters.PerPage))
+	return deliveries, &models.PaginationMeta{
+		Total:      &total,
+		Page:       filters.Page,
+		PerPage:    filters.PerPage,
+		TotalPages: &totalPages,
+	}, nil
+}
+
+// pengiriman beserta log setiap percobaan
+func (s *WebhookService) GetDelivery(id uint) (*models.WebhookDelivery, error) {
+	delivery, err := s.repo.GetDelivery(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDeliveryNotFound
+	}
+	return delivery, err
+}
+
+// kirim ulang pengiriman (biasanya dari dead-letter) dengan jatah retry baru, body sama persis
+func (s *WebhookService) Redeliver(id uint) (*models.WebhookDelivery, error) {
+	delivery, err := s.GetDelivery(id)
+	if err != nil {
+		return nil, err
+	}
+	hook, err := s.Get(delivery.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !hook.Active {
+		return nil, ErrWebhookInactive
+	}
+
+	if err := s.repo.Reschedule(delivery, time.Now()); err != nil {
+		return nil, err
+	}
+	s.notify()
+	return delivery, nil
+}
+
+// jalankan worker yang mengirim pengiriman jatuh tempo setiap PollInterval
+func (s *WebhookService) Start() {
+	go s.run()
+}
+
+// nama sink di relay outbox
+func (s *WebhookService) Name() string {
+	return "webhook"
+}
+
+// sink relay outbox: buat pengiriman untuk semua webhook aktif yang berlangganan event ini.
+// Error dikembalikan ke relay supaya event dicoba ulang
+func (s *WebhookService) Publish(ctx context.Context, event events.Event) error {
+	hooks, err := s.repo.ActiveFor(string(event.Type))
+	if err != nil {
+		return err
+	}
+	deliveries, err := s.deliveries(hooks, event)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CreateDeliveries(deliveries); err != nil {
+		return err
+	}
+	if len(deliveries) > 0 {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *WebhookService) deliveries(hooks []models.Webhook, event events.Event) ([]models.WebhookDelivery, error) {
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, 0, len(hooks))
+	for _, hook := range hooks {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventID:       event.ID,
+			EventType:     string(event.Type),
+			Payload:       string(payload),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+	return deliveries, nil
+}
+
+// bangunkan worker tanpa menunggu PollInterval
+func (s *WebhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *WebhookService) run() {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		s.dispatchDue()
+	}
+}
+
+// kirim semua pengiriman jatuh tempo, per batch paralel
+func (s *WebhookService) dispatchDue() {
+	for {
+		// lease lebih lama dari timeout kirim supaya tidak diambil ulang saat masih dikirim
+		deliveries, err := s.repo.ClaimDue(time.Now(), s.opts.Timeout+time.Minute, webhookBatchSize)
+		if err != nil {
+			log.Printf("Webhook: gagal mengambil pengiriman: %v", err)
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				s.attempt(delivery)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+	}
+}
+
+// satu percobaan kirim: sukses kalau penerima membalas 2xx, selain itu dijadwalkan
+// ulang dengan backoff atau masuk dead-letter kalau jatah percobaan habis
+func (s *WebhookService) attempt(delivery *models.WebhookDelivery) {
+	hook, err := s.repo.GetByID(delivery.WebhookID)
+	if err != nil {
+		log.Printf("Webhook: gagal mengambil webhook %d: %v", delivery.WebhookID, err)
+		return
+	}
+
+	entry := &models.WebhookDeliveryLog{DeliveryID: delivery.ID}
+	delivery.Attempts++
+	entry.Attempt = delivery.Attempts
+
+	if !hook.Active {
+		entry.Error = i18n.T(i18n.Default(), i18n.MsgWebhookInactive)
+		delivery.Attempts = s.opts.MaxAttempts
+	} else {
+		start := time.Now()
+		entry.StatusCode, entry.ResponseBody, err = s.send(hook, delivery)
+		entry.DurationMs = time.Since(start).Milliseconds()
+		if err != nil {
+			entry.Error = err.Error()
+		}
+	}
+
+	now := time.Now()
+	delivery.LastStatusCode = entry.StatusCode
+	delivery.LastError = entry.Error
+	switch {
+	case entry.Error == "":
+		delivery.Status = models.DeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.opts.MaxAttempts:
+		delivery.Status = models.DeliveryDead
+		delivery.NextAttemptAt = nil
+		log.Printf("Webhook: pengiriman %d ke %s masuk dead-letter setelah %d percobaan: %s", delivery.ID, hook.URL, delivery.Attempts, entry.Error)
+	default:
+		next := now.Add(s.backoff(delivery.Attempts))
+		delivery.Status = models.DeliveryPending
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := s.repo.SaveAttempt(delivery, entry); err != nil {
+		log.Printf("Webhook: gagal menyimpan hasil pengiriman %d: %v", delivery.ID, err)
+	}
+}
+
+// POST payload dengan header signature, error kalau gagal terhubung atau status bukan 2xx
+func (s *WebhookService) send(hook *models.Webhook, delivery *models.WebhookDelivery) (*int, string, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(webhook.HeaderEvent, delivery.EventType)
+	req.Header.Set(webhook.HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(hook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	preview, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, string(preview), fmt.Errorf("HTTP %d", status)
+	}
+	return &status, string(preview), nil
+}
+
+// jeda sebelum percobaan berikutnya, jitter sampai 20% supaya retry tidak serentak
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := s.opts.BackoffBase
+	for i := 1; i < attempts && delay < s.opts.BackoffMax; i++ {
+		delay *= 2
+	}
+	if s.opts.BackoffMax > 0 && delay > s.opts.BackoffMax {
+		delay = s.opts.BackoffMax
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// URL ke localhost / IP internal ditolak di awal, nama domain dicek lagi saat kirim
+func (s *WebhookService) checkURL(rawURL string) error {
+	if err := webhook.CheckURL(rawURL, s.opts.AllowPrivateNetworks); err != nil {
+		return Invalid(CodeValidation, i18n.MsgWebhookURLBlocked)
+	}
+	return nil
+}
+
+func (s *WebhookService) validateStruct(req interface{}) error {
+	if err := s.validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+	return nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
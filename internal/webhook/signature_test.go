@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	got := Sign("secret", 1700000000, []byte("{}"))
+	if !strings.HasPrefix(got, "sha256=") || len(got) != len("sha256=")+64 {
+		t.Fatalf("Sign = %q, want sha256=<64 hex>", got)
+	}
+	if got != Sign("secret", 1700000000, []byte("{}")) {
+		t.Fatal("Sign harus deterministik")
+	}
+	for name, other := range map[string]string{
+		"secret lain":    Sign("secret2", 1700000000, []byte("{}")),
+		"timestamp lain": Sign("secret", 1700000001, []byte("{}")),
+		"body lain":      Sign("secret", 1700000000, []byte("{ }")),
+	} {
+		if other == got {
+			t.Fatalf("%s menghasilkan signature yang sama", name)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"type":"menu.updated"}`)
+	now := time.Now().Unix()
+	ts := strconv.FormatInt(now, 10)
+	old := strconv.FormatInt(now-600, 10)
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		body      []byte
+		tolerance time.Duration
+		wantErr   error
+	}{
+		{name: "valid", signature: Sign(secret, now, body), timestamp: ts, body: body, tolerance: 5 * time.Minute},
+		{name: "tanpa signature", timestamp: ts, body: body, wantErr: ErrMissingSignature},
+		{name: "tanpa timestamp", signature: Sign(secret, now, body), body: body, wantErr: ErrMissingSignature},
+		{name: "timestamp bukan angka", signature: Sign(secret, now, body), timestamp: "kemarin", body: body, wantErr: ErrMissingSignature},
+		{name: "body diubah", signature: Sign(secret, now, body), timestamp: ts, body: []byte(`{"type":"menu.deleted"}`), wantErr: ErrInvalidSignature},
+		{name: "secret lain", signature: Sign("whsec_lain", now, body), timestamp: ts, body: body, wantErr: ErrInvalidSignature},
+		{name: "timestamp diganti", signature: Sign(secret, now, body), timestamp: strconv.FormatInt(now+1, 10), body: body, wantErr: ErrInvalidSignature},
+		{name: "tanpa prefix", signature: strings.TrimPrefix(Sign(secret, now, body), "sha256="), timestamp: ts, body: body, wantErr: ErrInvalidSignature},
+		{name: "di luar toleransi", signature: Sign(secret, now-600, body), timestamp: old, body: body, tolerance: 5 * time.Minute, wantErr: ErrExpiredTimestamp},
+		{name: "toleransi 0 tidak cek timestamp", signature: Sign(secret, now-600, body), timestamp: old, body: body},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(secret, tt.signature, tt.timestamp, tt.body, tt.tolerance)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewSecret()
+	if !strings.HasPrefix(a, "whsec_") || len(a) != len("whsec_")+64 || a == b {
+		t.Fatalf("NewSecret = %q, %q", a, b)
+	}
+}
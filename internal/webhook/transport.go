@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// alamat tujuan webhook ada di jaringan internal (loopback, private, link-local)
+var ErrBlockedAddress = errors.New("alamat jaringan internal tidak diizinkan")
+
+// http.Client untuk kirim webhook. Redirect tidak diikuti (3xx = gagal) dan tanpa
+// proxy dari environment. allowPrivate false = koneksi ke IP internal ditolak saat
+// dial, setelah DNS di-resolve, supaya URL webhook tidak bisa dipakai untuk SSRF
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = blockInternal
+	}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// cek URL saat webhook dibuat/diubah: host berupa IP internal atau localhost langsung
+// ditolak. Nama domain lain baru dicek saat dial karena hasil DNS bisa berubah
+func CheckURL(rawURL string, allowPrivate bool) error {
+	if allowPrivate {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrBlockedAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && IsInternal(addr) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+// IP loopback, private (RFC 1918 / RFC 4193), link-local (termasuk 169.254.169.254),
+// CGNAT, unspecified atau multicast
+func IsInternal(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() ||
+		addr.IsUnspecified() || cgnat.Contains(addr)
+}
+
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// Control net.Dialer, address sudah berupa IP hasil resolve
+func blockInternal(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	if IsInternal(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		blocked bool
+	}{
+		{"https://example.com/hook", false},
+		{"http://93.184.216.34/hook", false},
+		{"http://localhost:9090/", true},
+		{"http://api.localhost/", true},
+		{"http://127.0.0.1/", true},
+		{"http://10.1.2.3/", true},
+		{"http://192.168.1.1/", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"http://100.64.0.1/", true},
+		{"http://0.0.0.0/", true},
+		{"http://[::1]/", true},
+		{"http://[fd00::1]/", true},
+		{"http://[::ffff:127.0.0.1]/", true},
+	}
+	for _, tt := range tests {
+		err := CheckURL(tt.url, false)
+		if blocked := errors.Is(err, ErrBlockedAddress); blocked != tt.blocked {
+			t.Errorf("CheckURL(%q) = %v, blocked %v", tt.url, err, tt.blocked)
+		}
+		if err := CheckURL(tt.url, true); err != nil {
+			t.Errorf("CheckURL(%q, allowPrivate) = %v", tt.url, err)
+		}
+	}
+}
+
+func TestClientBlocksInternalAddressOnDial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := NewClient(time.Second, false).Post(server.URL, "application/json", nil)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("koneksi ke %s harus ditolak, err = %v", server.URL, err)
+	}
+
+	resp, err := NewClient(time.Second, true).Post(server.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("allowPrivate: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hook" {
+			http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+			return
+		}
+		t.Error("redirect diikuti")
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(time.Second, true).Post(server.URL+"/hook", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTemporaryRedirect {
+		t.Fatalf("status %d, harus 307 tanpa mengikuti redirect", resp.StatusCode)
+	}
+}